@@ -1,8 +1,20 @@
 // Package async provides APIs to handle asynchronous tasks without using channels.
+//
+// Combinators like Map, Then and FlatMap take a context at chain-construction time.
+// That context, including its values, is the one passed to the functions of the chain,
+// while the contexts used to create the input futures don't flow downstream.
+// Use GoInherit to keep the values of a context without its cancellation.
 package async
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime/pprof"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Future provides a mechanism to access the future result of asynchronous works.
@@ -27,8 +39,22 @@ type Future[T any] interface {
 	Done() <-chan struct{}
 }
 
+// Waitable is implemented by anything that signals completion via a channel.
+// Every Future satisfies Waitable regardless of its result type.
+type Waitable interface {
+	// Done returns a channel that's closed when the work is done.
+	Done() <-chan struct{}
+}
+
 // Go runs fn in a different goroutine and returns an instance of Future.
 // That instance of Future can be used to access result of the asynchronous function.
+// If fn panics, the panic is recovered and returned by Get as a *PanicError.
+//
+// The goroutine keeps running until fn returns, even if every caller stops waiting in Get.
+// Use GoCancel or GoScoped to make sure a well-behaved fn is stopped when its result is no longer needed.
+//
+// If ctx is already done, Go doesn't start a goroutine nor call fn,
+// and the returned Future is already failed with the context error.
 //
 // Example:
 //
@@ -43,25 +69,344 @@ type Future[T any] interface {
 //
 // Check Future APIs for more detail.
 func Go[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) Future[T] {
-	fut := &futureImpl[T]{
-		doneCh: make(chan struct{}),
+	if err := ctx.Err(); err != nil {
+		return Failed[T](err)
 	}
 
-	go func() {
+	fut := newFuture[T]()
+
+	go fut.run(ctx, fn)
+
+	return fut
+}
+
+// GoN starts n goroutines running fn, passing each its index, and returns their futures.
+// The future at index i of the returned slice is the result of fn called with i.
+//
+// Example:
+//
+//	futs := async.GoN(ctx, 3, func(ctx context.Context, i int) (Resp, error) {
+//		return query(ctx, replicas[i])
+//	})
+//
+//	resp, err := async.Any(ctx, futs).Get(ctx)
+func GoN[T any](ctx context.Context, n int, fn func(context.Context, int) (T, error)) []Future[T] {
+	futs := make([]Future[T], max(n, 0))
+	for i := range futs {
+		i := i
+		futs[i] = Go(ctx, func(ctx context.Context) (T, error) {
+			return fn(ctx, i)
+		})
+	}
+
+	return futs
+}
+
+// GoValue is like Go but for functions that can't fail.
+// The error returned by Get is only ever the context error, or a *PanicError if fn panics.
+func GoValue[T any](ctx context.Context, fn func(ctx context.Context) T) Future[T] {
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		return fn(ctx), nil
+	})
+}
+
+// GoNoCtx is like Go but for functions that don't need a context, e.g. pure CPU work.
+// Get still honors the context passed to it for waiting.
+func GoNoCtx[T any](fn func() (T, error)) Future[T] {
+	return Go(context.Background(), func(_ context.Context) (T, error) {
+		return fn()
+	})
+}
+
+// GoLabeled is like Go but runs the goroutine with the pprof label async.task=label,
+// so goroutine profiles show which futures are stuck. The label is also available to fn via pprof.Label.
+// Labels are cheap and have no effect when profiling isn't active.
+func GoLabeled[T any](ctx context.Context, label string, fn func(ctx context.Context) (T, error)) Future[T] {
+	if err := ctx.Err(); err != nil {
+		return Failed[T](err)
+	}
+
+	fut := newFuture[T]()
+
+	go pprof.Do(ctx, pprof.Labels("async.task", label), func(ctx context.Context) {
+		fut.run(ctx, fn)
+	})
+
+	return fut
+}
+
+// GoID is like Go but tags the result with id to tell which task failed, e.g. in logs.
+// Errors returned by fn are wrapped as `task "id": original error` and still unwrap to the original one,
+// and if fn panics, id is recorded in the ID field of the *PanicError.
+// If ctx is already done, fn isn't called and the context error is wrapped the same way.
+func GoID[T any](ctx context.Context, id string, fn func(ctx context.Context) (T, error)) Future[T] {
+	if err := ctx.Err(); err != nil {
+		return Failed[T](fmt.Errorf("task %q: %w", id, err))
+	}
+
+	fut := newFuture[T]()
+
+	go fut.runWithID(ctx, id, func(ctx context.Context) (T, error) {
 		val, err := fn(ctx)
-		fut.value = val
-		fut.err = err
-		close(fut.doneCh)
+		if err != nil {
+			return val, fmt.Errorf("task %q: %w", id, err)
+		}
+
+		return val, nil
+	})
+
+	return fut
+}
+
+// GoInherit is like Go but runs fn with a context that carries the values of parent,
+// e.g. request-scoped tracing spans, in a fresh cancellation scope.
+// Cancelling parent or hitting its deadline doesn't cancel the context of fn.
+func GoInherit[T any](parent context.Context, fn func(ctx context.Context) (T, error)) Future[T] {
+	return Go(context.WithoutCancel(parent), fn)
+}
+
+// GoCancel is like Go but runs fn with a cancellable context derived from ctx.
+// Calling the returned cancel function cancels the context of fn so a well-behaved fn can stop early,
+// in which case Get returns context.Canceled. Calling cancel multiple times is safe.
+//
+// Example:
+//
+//	fut, cancel := async.GoCancel(ctx, someWorkFn)
+//	defer cancel()
+func GoCancel[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) (Future[T], context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	return Go(ctx, fn), cancel
+}
+
+// Resolved returns a Future that is already done with v.
+// No goroutine is started. Since the result is already available,
+// Get returns v even if the provided context is already cancelled.
+func Resolved[T any](v T) Future[T] {
+	fut := newFuture[T]()
+	fut.resolve(v, nil)
+	return fut
+}
+
+// Failed returns a Future that is already done with err.
+// No goroutine is started. Like Resolved, Get returns err even if the provided context is already cancelled.
+func Failed[T any](err error) Future[T] {
+	var zero T
+	fut := newFuture[T]()
+	fut.resolve(zero, err)
+	return fut
+}
+
+// closeWait is how long the io.Closer returned by GoCloser waits for the worker to return.
+const closeWait = 100 * time.Millisecond
+
+// ErrCloseTimeout is returned by the io.Closer of GoCloser when the worker doesn't return in time after being cancelled.
+var ErrCloseTimeout = errors.New("async: close timed out waiting for the worker")
+
+// GoCloser is like GoCancel but returns an io.Closer to give up on the result deterministically,
+// e.g. in request handlers returning early. Close cancels the context of fn and waits briefly for fn to return.
+// It returns ErrCloseTimeout if fn is still running after the wait, and nil otherwise.
+// Close is idempotent, always returning the same error, and it's safe to call after completion.
+//
+// Example:
+//
+//	fut, closer := async.GoCloser(ctx, someWorkFn)
+//	defer closer.Close()
+func GoCloser[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) (Future[T], io.Closer) {
+	fut, cancel := GoCancel(ctx, fn)
+	return fut, &futureCloser{
+		w:      fut,
+		cancel: cancel,
+	}
+}
+
+// futureCloser implements io.Closer for GoCloser.
+type futureCloser struct {
+	w      Waitable
+	cancel context.CancelFunc
+	once   sync.Once
+	err    error
+}
+
+func (c *futureCloser) Close() error {
+	c.once.Do(func() {
+		c.cancel()
+		if !awaitWithin(c.w, closeWait) {
+			c.err = ErrCloseTimeout
+		}
+	})
+
+	return c.err
+}
+
+// Settle returns a Future that isn't done yet, along with the function settling it with a result.
+// It bridges completion sources other than goroutines, e.g. callback-based APIs, into futures.
+// The settle function must be called exactly once, and it panics if called again.
+//
+// Example:
+//
+//	fut, settle := async.Settle[Message]()
+//	client.OnMessage(func(msg Message, err error) {
+//		settle(msg, err)
+//	})
+func Settle[T any]() (Future[T], func(T, error)) {
+	fut := newFuture[T]()
+
+	var settled atomic.Bool
+	return fut, func(val T, err error) {
+		if settled.Swap(true) {
+			panic("async: future settled more than once")
+		}
+
+		fut.resolve(val, err)
+	}
+}
+
+// GoWithCancelCause is like GoCancel but the returned function cancels with a cause.
+// fn can read the cause via context.Cause, and if fn fails with the context error,
+// Get returns the cause instead of the generic context.Canceled.
+//
+// Example:
+//
+//	fut, cancel := async.GoWithCancelCause(ctx, someWorkFn)
+//	cancel(errShuttingDown)
+//	_, err := fut.Get(ctx) // err is errShuttingDown
+func GoWithCancelCause[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) (Future[T], context.CancelCauseFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		val, err := fn(ctx)
+		if ctxErr := ctx.Err(); err != nil && ctxErr != nil && errors.Is(err, ctxErr) {
+			return val, context.Cause(ctx)
+		}
+
+		return val, err
+	}), cancel
+}
+
+// GoWithCleanup is like Go but calls cleanup once fn returns or panics, before the future is done,
+// e.g. to release a pooled connection. cleanup is called exactly once,
+// and a panic in fn is still returned by Get as a *PanicError.
+func GoWithCleanup[T any](ctx context.Context, fn func(ctx context.Context) (T, error), cleanup func()) Future[T] {
+	fut := newFuture[T]()
+
+	go fut.run(ctx, func(ctx context.Context) (T, error) {
+		defer cleanup()
+		return fn(ctx)
+	})
+
+	return fut
+}
+
+// GoScoped is like Go but cancels the context of fn once its result is abandoned,
+// i.e. when the last caller waiting in Get returns early because its own context is done.
+// This prevents leaking the goroutine when callers time out, provided fn honors its context.
+// Once cancelled, the Future resolves with whatever fn returns, usually the context error.
+// Waiting via Done doesn't count as waiting for the result.
+func GoScoped[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) Future[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	fut := &scopedFuture[T]{
+		futureImpl: newFuture[T](),
+		cancel:     cancel,
+	}
+
+	go func() {
+		defer cancel()
+		fut.run(ctx, fn)
 	}()
 
 	return fut
 }
 
+// scopedFuture is an implementation of Future that cancels the work once nobody is waiting for it.
+type scopedFuture[T any] struct {
+	*futureImpl[T]
+	cancel  context.CancelFunc
+	mu      sync.Mutex
+	waiters int
+}
+
+func (f *scopedFuture[T]) Get(ctx context.Context) (T, error) {
+	f.mu.Lock()
+	f.waiters++
+	f.mu.Unlock()
+
+	val, err := f.futureImpl.Get(ctx)
+
+	f.mu.Lock()
+	f.waiters--
+	abandoned := f.waiters == 0 && !isDone(f.futureImpl)
+	f.mu.Unlock()
+
+	if abandoned {
+		f.cancel()
+	}
+
+	return val, err
+}
+
 // futureImpl is the an implementation of Feature.
+//
+// Instances are deliberately not recycled via sync.Pool: a Future is handed to callers
+// who may keep it and call Get at any time, so there's no point at which it's safe to reset one
+// without risking a stale holder observing the result of another task.
 type futureImpl[T any] struct {
-	doneCh chan struct{}
-	value  T
-	err    error
+	doneCh   chan struct{}
+	value    T
+	err      error
+	panicked bool
+}
+
+// newFuture returns an unresolved futureImpl.
+func newFuture[T any]() *futureImpl[T] {
+	return &futureImpl[T]{
+		doneCh: make(chan struct{}),
+	}
+}
+
+// resolve stores the result and marks the future as done.
+// It must be called exactly once.
+func (f *futureImpl[T]) resolve(val T, err error) {
+	f.value = val
+	f.err = err
+	close(f.doneCh)
+}
+
+// run calls fn and resolves f with its result.
+// If fn panics, f is resolved with a *PanicError and recorded as panicked.
+func (f *futureImpl[T]) run(ctx context.Context, fn func(ctx context.Context) (T, error)) {
+	f.runWithID(ctx, "", fn)
+}
+
+// runWithID is like run but records id on the *PanicError if fn panics.
+func (f *futureImpl[T]) runWithID(ctx context.Context, id string, fn func(ctx context.Context) (T, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			panicErr := newPanicError(r)
+			panicErr.ID = id
+			f.panicked = true
+			f.resolve(zero, panicErr)
+		}
+	}()
+
+	f.resolve(fn(ctx))
+}
+
+// call is like safeCall but records f as panicked if fn panics.
+// It's for helpers whose bookkeeping needs the outcome of fn before f is resolved.
+func (f *futureImpl[T]) call(ctx context.Context, fn func(ctx context.Context) (T, error)) (val T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = newPanicError(r)
+			f.panicked = true
+		}
+	}()
+
+	return fn(ctx)
+}
+
+// Panicked reports whether f is done because its function panicked.
+func (f *futureImpl[T]) Panicked() bool {
+	return isDone(f) && f.panicked
 }
 
 func (f *futureImpl[T]) Done() <-chan struct{} {
@@ -69,6 +414,13 @@ func (f *futureImpl[T]) Done() <-chan struct{} {
 }
 
 func (f *futureImpl[T]) Get(ctx context.Context) (resp T, err error) {
+	// prefer the result when it's already available
+	select {
+	case <-f.doneCh:
+		return f.value, f.err
+	default:
+	}
+
 	select {
 	case <-f.doneCh:
 		resp = f.value
@@ -79,3 +431,91 @@ func (f *futureImpl[T]) Get(ctx context.Context) (resp T, err error) {
 		return
 	}
 }
+
+// isDone reports whether w is done without blocking.
+func isDone(w Waitable) bool {
+	select {
+	case <-w.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// IsDone reports whether fut is done without blocking or consuming its result.
+// It's true as soon as the Done channel of fut is closed.
+func IsDone[T any](fut Future[T]) bool {
+	return isDone(fut)
+}
+
+// Subscribe returns a new channel that is closed once fut completes, so several components
+// can each own a channel to react to the same future. If fut is already done, the returned channel is already closed.
+// Otherwise a goroutine waits on the Done channel of fut, and it stays blocked until fut completes.
+func Subscribe[T any](fut Future[T]) <-chan struct{} {
+	ch := make(chan struct{})
+	if isDone(fut) {
+		close(ch)
+		return ch
+	}
+
+	go func() {
+		<-fut.Done()
+		close(ch)
+	}()
+
+	return ch
+}
+
+// Peek returns the result of fut without blocking.
+// ok is false if fut is not done yet, in which case val and err are zero values.
+// Reading the result is safe once ok is true since the done channel is closed only after the result is stored.
+func Peek[T any](fut Future[T]) (val T, err error, ok bool) { //nolint:revive // ok is last to mirror the comma-ok idiom
+	if !isDone(fut) {
+		return val, nil, false
+	}
+
+	val, err = fut.Get(context.Background())
+	return val, err, true
+}
+
+// MustGet returns the result of fut without a context.
+// It's only valid once fut is done, e.g. right after Done is closed in a select, and panics otherwise.
+func MustGet[T any](fut Future[T]) (T, error) {
+	if !isDone(fut) {
+		panic("async: MustGet called before the future is done")
+	}
+
+	return fut.Get(context.Background())
+}
+
+// Panicked reports whether fut is done because its own function panicked,
+// as opposed to failing with an error, which might be a *PanicError propagated from another future.
+// It returns false for futures that are not done yet or that don't track panics.
+func Panicked[T any](fut Future[T]) bool {
+	p, ok := fut.(interface{ Panicked() bool })
+	return ok && p.Panicked()
+}
+
+// Must calls Get and returns the value, panicking if the error is non-nil.
+// The panic value is an error wrapping the original one.
+// It's intended for scripts and test setup where an error is unexpected.
+func Must[T any](fut Future[T], ctx context.Context) T { //nolint:revive // fut first reads naturally as Must(fut, ctx)
+	val, err := fut.Get(ctx)
+	if err != nil {
+		panic(fmt.Errorf("async: Must: %w", err))
+	}
+
+	return val
+}
+
+// GetOrDefault waits for fut like Get and returns its value, or def if it fails for any reason,
+// including ctx being done first. Errors are silently swallowed,
+// so it's only meant for best-effort reads and must not be used where failures matter.
+func GetOrDefault[T any](ctx context.Context, fut Future[T], def T) T {
+	val, err := fut.Get(ctx)
+	if err != nil {
+		return def
+	}
+
+	return val
+}
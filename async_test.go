@@ -2,6 +2,10 @@ package async_test
 
 import (
 	"context"
+	"errors"
+	"runtime/pprof"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -39,6 +43,29 @@ func TestAsync_Get(t *testing.T) {
 		}
 	})
 
+	t.Run("should not call fn when context is already cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var called atomic.Bool
+		fut := async.Go(ctx, func(ctx context.Context) (int, error) {
+			called.Store(true)
+			return 1, nil
+		})
+
+		if !async.IsDone(fut) {
+			t.Fatal("Expected the future to be done already")
+		}
+
+		if _, err := fut.Get(context.Background()); err != context.Canceled {
+			t.Fatalf("Expected %v, but got %v", context.Canceled, err)
+		}
+
+		if called.Load() {
+			t.Fatal("Expected fn not to be called")
+		}
+	})
+
 	t.Run("should return an error when context's deadline passes", func(t *testing.T) {
 		testEndCh := make(chan struct{})
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
@@ -77,3 +104,784 @@ func TestAsync_Done(t *testing.T) {
 		}
 	})
 }
+
+func TestGoCancel(t *testing.T) {
+	t.Run("should cancel the context of fn", func(t *testing.T) {
+		fut, cancel := async.GoCancel(context.Background(), func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+
+		cancel()
+		cancel()
+
+		_, err := fut.Get(context.Background())
+		if err != context.Canceled {
+			t.Fatalf("Expected %v, but got %v", context.Canceled, err)
+		}
+	})
+
+	t.Run("should return a response when it's not cancelled", func(t *testing.T) {
+		fut, cancel := async.GoCancel(context.Background(), func(ctx context.Context) (int, error) {
+			return 1, nil
+		})
+		defer cancel()
+
+		resp, err := fut.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != 1 {
+			t.Fatalf("Expected a response, but got %v", resp)
+		}
+	})
+}
+
+func TestAsync_Panic(t *testing.T) {
+	t.Run("should return a PanicError when fn panics", func(t *testing.T) {
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			panic("boom")
+		})
+
+		select {
+		case <-fut.Done():
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("test timed out")
+		}
+
+		_, err := fut.Get(context.Background())
+		var panicErr *async.PanicError
+		if !errors.As(err, &panicErr) {
+			t.Fatalf("Expected a PanicError, but got %v", err)
+		}
+
+		if panicErr.Value != "boom" {
+			t.Fatalf("Expected %q, but got %v", "boom", panicErr.Value)
+		}
+
+		if len(panicErr.Stack) == 0 {
+			t.Fatal("Expected a stack trace")
+		}
+	})
+}
+
+func TestResolved(t *testing.T) {
+	t.Run("should return the value immediately", func(t *testing.T) {
+		fut := async.Resolved(1)
+
+		select {
+		case <-fut.Done():
+		default:
+			t.Fatal("Expected the future to be done")
+		}
+
+		resp, err := fut.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != 1 {
+			t.Fatalf("Expected a response, but got %v", resp)
+		}
+	})
+
+	t.Run("should return the value even if the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		resp, err := async.Resolved(1).Get(ctx)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != 1 {
+			t.Fatalf("Expected a response, but got %v", resp)
+		}
+	})
+}
+
+func TestFailed(t *testing.T) {
+	t.Run("should return the error immediately", func(t *testing.T) {
+		fut := async.Failed[int](errTest)
+
+		select {
+		case <-fut.Done():
+		default:
+			t.Fatal("Expected the future to be done")
+		}
+
+		_, err := fut.Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+}
+
+func TestGoCloser(t *testing.T) {
+	t.Run("should cancel the worker and wait for it", func(t *testing.T) {
+		var stopped atomic.Bool
+		fut, closer := async.GoCloser(context.Background(), func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			stopped.Store(true)
+			return 0, ctx.Err()
+		})
+
+		if err := closer.Close(); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if !stopped.Load() || !async.IsDone(fut) {
+			t.Fatal("Expected the worker to have returned")
+		}
+
+		if err := closer.Close(); err != nil {
+			t.Fatalf("Expected no error on the second call, but got %v", err)
+		}
+	})
+
+	t.Run("should be safe to call after completion", func(t *testing.T) {
+		fut, closer := async.GoCloser(context.Background(), func(ctx context.Context) (int, error) {
+			return 1, nil
+		})
+
+		<-fut.Done()
+		if err := closer.Close(); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp, err := fut.Get(context.Background()); err != nil || resp != 1 {
+			t.Fatalf("Expected 1, but got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("should time out when the worker ignores cancellation", func(t *testing.T) {
+		releaseCh := make(chan struct{})
+		defer close(releaseCh)
+
+		_, closer := async.GoCloser(context.Background(), func(ctx context.Context) (int, error) {
+			<-releaseCh
+			return 1, nil
+		})
+
+		if err := closer.Close(); err != async.ErrCloseTimeout {
+			t.Fatalf("Expected %v, but got %v", async.ErrCloseTimeout, err)
+		}
+	})
+}
+
+func TestSettle(t *testing.T) {
+	t.Run("should complete the future when settled", func(t *testing.T) {
+		fut, settle := async.Settle[int]()
+		if async.IsDone(fut) {
+			t.Fatal("Expected the future not to be done")
+		}
+
+		go settle(1, errTest)
+
+		resp, err := fut.Get(context.Background())
+		if resp != 1 || err != errTest {
+			t.Fatalf("Expected 1, %v, but got %v, %v", errTest, resp, err)
+		}
+	})
+
+	t.Run("should panic when settled twice", func(t *testing.T) {
+		_, settle := async.Settle[int]()
+		settle(1, nil)
+
+		defer func() {
+			if recover() == nil {
+				t.Fatal("Expected a panic")
+			}
+		}()
+
+		settle(2, nil)
+	})
+}
+
+func TestPeek(t *testing.T) {
+	t.Run("should return false when the future is not done", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+
+		_, _, ok := async.Peek(fut)
+		if ok {
+			t.Fatal("Expected the future not to be done")
+		}
+	})
+
+	t.Run("should return the result when the future is done", func(t *testing.T) {
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			return 1, nil
+		})
+		<-fut.Done()
+
+		resp, err, ok := async.Peek(fut)
+		if !ok {
+			t.Fatal("Expected the future to be done")
+		}
+
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != 1 {
+			t.Fatalf("Expected a response, but got %v", resp)
+		}
+	})
+}
+
+func TestGoScoped(t *testing.T) {
+	t.Run("should cancel fn when the last waiter gives up", func(t *testing.T) {
+		stoppedCh := make(chan struct{})
+		fut := async.GoScoped(context.Background(), func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			close(stoppedCh)
+			return 0, ctx.Err()
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := fut.Get(ctx)
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+
+		select {
+		case <-stoppedCh:
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("Expected fn to be stopped")
+		}
+	})
+
+	t.Run("should not cancel fn while another caller is waiting", func(t *testing.T) {
+		releaseCh := make(chan struct{})
+		fut := async.GoScoped(context.Background(), func(ctx context.Context) (int, error) {
+			select {
+			case <-releaseCh:
+				return 1, nil
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		})
+
+		resultCh := make(chan error, 1)
+		go func() {
+			_, err := fut.Get(context.Background())
+			resultCh <- err
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		// give the other caller time to start waiting
+		time.Sleep(time.Millisecond)
+		_, _ = fut.Get(ctx)
+		close(releaseCh)
+
+		if err := <-resultCh; err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+	})
+}
+
+func TestGoValue(t *testing.T) {
+	t.Run("should return the value", func(t *testing.T) {
+		fut := async.GoValue(context.Background(), func(ctx context.Context) int {
+			return 1
+		})
+
+		resp, err := fut.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != 1 {
+			t.Fatalf("Expected a response, but got %v", resp)
+		}
+	})
+}
+
+func TestMustGet(t *testing.T) {
+	t.Run("should return the result when the future is done", func(t *testing.T) {
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			return 1, nil
+		})
+
+		<-fut.Done()
+		resp, err := async.MustGet(fut)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != 1 {
+			t.Fatalf("Expected a response, but got %v", resp)
+		}
+	})
+
+	t.Run("should panic when the future is not done", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("Expected a panic")
+			}
+		}()
+
+		_, _ = async.MustGet(fut)
+	})
+}
+
+func TestGoN(t *testing.T) {
+	t.Run("should return futures in index order", func(t *testing.T) {
+		futs := async.GoN(context.Background(), 5, func(ctx context.Context, i int) (int, error) {
+			time.Sleep(time.Duration(5-i) * time.Millisecond)
+			return i * 10, nil
+		})
+
+		if len(futs) != 5 {
+			t.Fatalf("Expected 5 futures, but got %v", len(futs))
+		}
+
+		for i, fut := range futs {
+			resp, err := fut.Get(context.Background())
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+
+			if resp != i*10 {
+				t.Fatalf("Expected %v, but got %v", i*10, resp)
+			}
+		}
+	})
+}
+
+func TestGoInherit(t *testing.T) {
+	t.Run("should pass the values of the parent to fn", func(t *testing.T) {
+		parent := context.WithValue(context.Background(), ctxKey{}, "span")
+
+		resp, err := async.GoInherit(parent, func(ctx context.Context) (string, error) {
+			v, _ := ctx.Value(ctxKey{}).(string)
+			return v, nil
+		}).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != "span" {
+			t.Fatalf("Expected %q, but got %q", "span", resp)
+		}
+	})
+
+	t.Run("should not cancel fn when the parent is cancelled", func(t *testing.T) {
+		parent, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := async.GoInherit(parent, func(ctx context.Context) (int, error) {
+			return 1, ctx.Err()
+		}).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+	})
+}
+
+func TestPanicked(t *testing.T) {
+	t.Run("should report a panic distinctly", func(t *testing.T) {
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			panic(errTest)
+		})
+
+		select {
+		case <-fut.Done():
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("test timed out")
+		}
+
+		if !async.Panicked(fut) {
+			t.Fatal("Expected the future to be panicked")
+		}
+
+		_, err := fut.Get(context.Background())
+		var panicErr *async.PanicError
+		if !errors.As(err, &panicErr) || len(panicErr.Stack) == 0 {
+			t.Fatalf("Expected a PanicError with a stack trace, but got %v", err)
+		}
+
+		if !errors.Is(err, errTest) {
+			t.Fatalf("Expected %v to unwrap to %v", err, errTest)
+		}
+	})
+
+	t.Run("should not report a regular error as a panic", func(t *testing.T) {
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			return 0, errTest
+		})
+		<-fut.Done()
+
+		if async.Panicked(fut) {
+			t.Fatal("Expected the future not to be panicked")
+		}
+	})
+
+	t.Run("should not report a propagated panic", func(t *testing.T) {
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			panic("boom")
+		})
+		mapped := async.Map(context.Background(), fut, func(v int) int { return v })
+		<-mapped.Done()
+
+		if async.Panicked(mapped) {
+			t.Fatal("Expected the mapped future not to be panicked")
+		}
+	})
+}
+
+func TestGoNoCtx(t *testing.T) {
+	t.Run("should return the result of fn", func(t *testing.T) {
+		resp, err := async.GoNoCtx(func() (int, error) {
+			return 1, nil
+		}).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != 1 {
+			t.Fatalf("Expected a response, but got %v", resp)
+		}
+	})
+
+	t.Run("should honor the context of Get", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		fut := async.GoNoCtx(func() (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := fut.Get(ctx)
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+func TestGoLabeled(t *testing.T) {
+	t.Run("should label the goroutine", func(t *testing.T) {
+		resp, err := async.GoLabeled(context.Background(), "fetch-user", func(ctx context.Context) (string, error) {
+			label, _ := pprof.Label(ctx, "async.task")
+			return label, nil
+		}).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != "fetch-user" {
+			t.Fatalf("Expected %q, but got %q", "fetch-user", resp)
+		}
+	})
+
+	t.Run("should not call fn when ctx is already done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var called atomic.Bool
+		_, err := async.GoLabeled(ctx, "fetch-user", func(ctx context.Context) (string, error) {
+			called.Store(true)
+			return "", nil
+		}).Get(context.Background())
+		if err != context.Canceled {
+			t.Fatalf("Expected %v, but got %v", context.Canceled, err)
+		}
+
+		if called.Load() {
+			t.Fatal("Expected fn not to be called")
+		}
+	})
+}
+
+func TestMust(t *testing.T) {
+	t.Run("should return the value when there is no error", func(t *testing.T) {
+		if resp := async.Must(async.Resolved(1), context.Background()); resp != 1 {
+			t.Fatalf("Expected a response, but got %v", resp)
+		}
+	})
+
+	t.Run("should panic with the wrapped error", func(t *testing.T) {
+		defer func() {
+			err, _ := recover().(error)
+			if !errors.Is(err, errTest) {
+				t.Fatalf("Expected a panic wrapping %v, but got %v", errTest, err)
+			}
+		}()
+
+		async.Must(async.Failed[int](errTest), context.Background())
+	})
+}
+
+func TestGetOrDefault(t *testing.T) {
+	t.Run("should return the value when there is no error", func(t *testing.T) {
+		if resp := async.GetOrDefault(context.Background(), async.Resolved(1), -1); resp != 1 {
+			t.Fatalf("Expected 1, but got %v", resp)
+		}
+	})
+
+	t.Run("should return the default when the future fails", func(t *testing.T) {
+		if resp := async.GetOrDefault(context.Background(), async.Failed[int](errTest), -1); resp != -1 {
+			t.Fatalf("Expected -1, but got %v", resp)
+		}
+	})
+
+	t.Run("should return the default when the context is done", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if resp := async.GetOrDefault(ctx, fut, -1); resp != -1 {
+			t.Fatalf("Expected -1, but got %v", resp)
+		}
+	})
+}
+
+func TestGoWithCancelCause(t *testing.T) {
+	t.Run("should return the cause through Get", func(t *testing.T) {
+		errCause := errors.New("shutting down")
+		fut, cancel := async.GoWithCancelCause(context.Background(), func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			if cause := context.Cause(ctx); cause != errCause {
+				t.Errorf("Expected %v, but got %v", errCause, cause)
+			}
+
+			return 0, ctx.Err()
+		})
+
+		cancel(errCause)
+
+		_, err := fut.Get(context.Background())
+		if err != errCause {
+			t.Fatalf("Expected %v, but got %v", errCause, err)
+		}
+	})
+
+	t.Run("should return other errors untouched", func(t *testing.T) {
+		fut, cancel := async.GoWithCancelCause(context.Background(), func(ctx context.Context) (int, error) {
+			return 0, errTest
+		})
+		defer cancel(nil)
+
+		_, err := fut.Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+}
+
+func TestIsDone(t *testing.T) {
+	t.Run("should reflect the state of the future", func(t *testing.T) {
+		releaseCh := make(chan struct{})
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-releaseCh
+			return 1, nil
+		})
+
+		if async.IsDone(fut) {
+			t.Fatal("Expected the future not to be done")
+		}
+
+		close(releaseCh)
+		<-fut.Done()
+
+		if !async.IsDone(fut) {
+			t.Fatal("Expected the future to be done")
+		}
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	t.Run("should notify every subscriber", func(t *testing.T) {
+		releaseCh := make(chan struct{})
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-releaseCh
+			return 1, nil
+		})
+
+		subs := []<-chan struct{}{async.Subscribe(fut), async.Subscribe(fut)}
+		if subs[0] == subs[1] {
+			t.Fatal("Expected each subscriber to get its own channel")
+		}
+
+		close(releaseCh)
+		for _, sub := range subs {
+			select {
+			case <-sub:
+			case <-time.After(time.Second):
+				t.Fatal("Expected the subscriber to be notified")
+			}
+		}
+	})
+
+	t.Run("should return a closed channel when already done", func(t *testing.T) {
+		select {
+		case <-async.Subscribe(async.Resolved(1)):
+		default:
+			t.Fatal("Expected the channel to be closed")
+		}
+	})
+}
+
+func TestGoWithCleanup(t *testing.T) {
+	t.Run("should call cleanup before the future is done", func(t *testing.T) {
+		cleanups := 0
+		fut := async.GoWithCleanup(context.Background(), func(ctx context.Context) (int, error) {
+			return 1, nil
+		}, func() {
+			cleanups++
+		})
+
+		<-fut.Done()
+		if cleanups != 1 {
+			t.Fatalf("Expected cleanup to be called once, but got %v", cleanups)
+		}
+	})
+
+	t.Run("should call cleanup once on panic", func(t *testing.T) {
+		cleanups := 0
+		fut := async.GoWithCleanup(context.Background(), func(ctx context.Context) (int, error) {
+			panic("boom")
+		}, func() {
+			cleanups++
+		})
+
+		_, err := fut.Get(context.Background())
+		var panicErr *async.PanicError
+		if !errors.As(err, &panicErr) {
+			t.Fatalf("Expected a PanicError, but got %v", err)
+		}
+
+		if cleanups != 1 {
+			t.Fatalf("Expected cleanup to be called once, but got %v", cleanups)
+		}
+	})
+}
+
+func TestGoID(t *testing.T) {
+	t.Run("should wrap errors with the task id", func(t *testing.T) {
+		_, err := async.GoID(context.Background(), "fetch-user", func(ctx context.Context) (int, error) {
+			return 0, errTest
+		}).Get(context.Background())
+
+		if !errors.Is(err, errTest) {
+			t.Fatalf("Expected %v to wrap %v", err, errTest)
+		}
+
+		if !strings.Contains(err.Error(), `"fetch-user"`) {
+			t.Fatalf("Expected %q to contain the task id", err.Error())
+		}
+	})
+
+	t.Run("should return the value untouched on success", func(t *testing.T) {
+		resp, err := async.GoID(context.Background(), "fetch-user", func(ctx context.Context) (int, error) {
+			return 1, nil
+		}).Get(context.Background())
+
+		if err != nil || resp != 1 {
+			t.Fatalf("Expected 1, but got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("should not call fn when ctx is already done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var called atomic.Bool
+		_, err := async.GoID(ctx, "fetch-user", func(ctx context.Context) (int, error) {
+			called.Store(true)
+			return 0, nil
+		}).Get(context.Background())
+		if !errors.Is(err, context.Canceled) || !strings.Contains(err.Error(), `"fetch-user"`) {
+			t.Fatalf("Expected %v to wrap %v with the task id", err, context.Canceled)
+		}
+
+		if called.Load() {
+			t.Fatal("Expected fn not to be called")
+		}
+	})
+
+	t.Run("should record the task id on panic", func(t *testing.T) {
+		fut := async.GoID(context.Background(), "fetch-user", func(ctx context.Context) (int, error) {
+			panic(errTest)
+		})
+
+		_, err := fut.Get(context.Background())
+		var panicErr *async.PanicError
+		if !errors.As(err, &panicErr) || panicErr.ID != "fetch-user" {
+			t.Fatalf("Expected a PanicError with the task id, but got %v", err)
+		}
+
+		if !errors.Is(err, errTest) {
+			t.Fatalf("Expected %v to wrap %v", err, errTest)
+		}
+
+		if !async.Panicked(fut) {
+			t.Fatal("Expected the future to be recorded as panicked")
+		}
+	})
+}
+
+func BenchmarkGo(b *testing.B) {
+	ctx := context.Background()
+	fn := func(ctx context.Context) (int, error) {
+		return 1, nil
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = async.Go(ctx, fn).Get(ctx)
+	}
+}
+
+func BenchmarkResolved(b *testing.B) {
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = async.Resolved(i).Get(ctx)
+	}
+}
+
+func BenchmarkGo_CancelledContext(b *testing.B) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fn := func(ctx context.Context) (int, error) {
+		return 1, nil
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = async.Go(ctx, fn).Get(ctx)
+	}
+}
@@ -0,0 +1,130 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by futures executed through an open CircuitBreaker.
+var ErrCircuitOpen = errors.New("async: circuit open")
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// CircuitBreaker stops calling a failing dependency for a while to give it time to recover.
+// It opens after a number of consecutive failures and rejects calls with ErrCircuitOpen during a cooldown.
+// After the cooldown, a single trial call is let through: if it succeeds the breaker closes, otherwise it opens again.
+// Outcomes of calls only count for the state they were admitted in, so a slow call admitted
+// while the breaker was closed doesn't affect the trial of a half-open one.
+// It's safe for concurrent use.
+type CircuitBreaker struct {
+	mu         sync.Mutex
+	threshold  int
+	cooldown   time.Duration
+	state      circuitState
+	generation uint64
+	failures   int
+	openedAt   time.Time
+}
+
+// NewCircuitBreaker creates a closed CircuitBreaker that opens after threshold consecutive failures
+// and stays open for cooldown. threshold <= 0 is treated as 1.
+//
+// Example:
+//
+//	cb := async.NewCircuitBreaker(5, 10*time.Second)
+//	fut := async.Execute(cb, ctx, callDependency)
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: max(threshold, 1),
+		cooldown:  cooldown,
+	}
+}
+
+// Execute runs fn in a new goroutine like Go if cb allows it, and records the outcome in cb.
+// Errors and panics of fn count as failures. If cb is open, or a half-open trial is already in flight,
+// fn isn't called and the returned Future fails with ErrCircuitOpen.
+func Execute[T any](cb *CircuitBreaker, ctx context.Context, fn func(ctx context.Context) (T, error)) Future[T] { //nolint:revive // the breaker first reads naturally as Execute(cb, ctx, fn)
+	generation, ok := cb.allow()
+	if !ok {
+		return Failed[T](ErrCircuitOpen)
+	}
+
+	fut := newFuture[T]()
+	go fut.run(ctx, func(ctx context.Context) (T, error) {
+		succeeded := false
+		defer func() {
+			cb.record(generation, succeeded)
+		}()
+
+		val, err := fn(ctx)
+		succeeded = err == nil
+		return val, err
+	})
+
+	return fut
+}
+
+// allow reports whether a call can go through, moving an open breaker to half-open once the cooldown has passed.
+// It also returns the generation of the state the call is admitted in.
+func (cb *CircuitBreaker) allow() (uint64, bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case circuitOpen:
+		if time.Since(cb.openedAt) < cb.cooldown {
+			return 0, false
+		}
+
+		cb.transition(circuitHalfOpen)
+		return cb.generation, true
+	case circuitHalfOpen:
+		return 0, false
+	default:
+		return cb.generation, true
+	}
+}
+
+// record updates the state of cb with the outcome of a call admitted in generation.
+// Outcomes of calls admitted before the latest state transition are ignored.
+func (cb *CircuitBreaker) record(generation uint64, succeeded bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if generation != cb.generation {
+		return
+	}
+
+	if succeeded {
+		if cb.state == circuitHalfOpen {
+			cb.transition(circuitClosed)
+		}
+
+		cb.failures = 0
+		return
+	}
+
+	cb.failures++
+	if cb.state == circuitHalfOpen || cb.failures >= cb.threshold {
+		cb.transition(circuitOpen)
+	}
+}
+
+// transition moves cb to state, starting a new generation.
+// It must be called with cb.mu held.
+func (cb *CircuitBreaker) transition(state circuitState) {
+	cb.state = state
+	cb.generation++
+	cb.failures = 0
+	if state == circuitOpen {
+		cb.openedAt = time.Now()
+	}
+}
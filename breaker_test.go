@@ -0,0 +1,122 @@
+package async_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	fail := func(ctx context.Context) (int, error) {
+		return 0, errTest
+	}
+	succeed := func(ctx context.Context) (int, error) {
+		return 1, nil
+	}
+
+	t.Run("should open after threshold consecutive failures", func(t *testing.T) {
+		cb := async.NewCircuitBreaker(2, time.Minute)
+
+		for i := 0; i < 2; i++ {
+			if _, err := async.Execute(cb, context.Background(), fail).Get(context.Background()); err != errTest {
+				t.Fatalf("Expected %v, but got %v", errTest, err)
+			}
+		}
+
+		if _, err := async.Execute(cb, context.Background(), succeed).Get(context.Background()); err != async.ErrCircuitOpen {
+			t.Fatalf("Expected %v, but got %v", async.ErrCircuitOpen, err)
+		}
+	})
+
+	t.Run("should reset the failure count on success", func(t *testing.T) {
+		cb := async.NewCircuitBreaker(2, time.Minute)
+
+		for _, fn := range []func(context.Context) (int, error){fail, succeed, fail, succeed} {
+			_, _ = async.Execute(cb, context.Background(), fn).Get(context.Background())
+		}
+
+		if _, err := async.Execute(cb, context.Background(), succeed).Get(context.Background()); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+	})
+
+	t.Run("should close after a successful half-open trial", func(t *testing.T) {
+		cb := async.NewCircuitBreaker(1, 10*time.Millisecond)
+		_, _ = async.Execute(cb, context.Background(), fail).Get(context.Background())
+
+		time.Sleep(20 * time.Millisecond)
+		if _, err := async.Execute(cb, context.Background(), succeed).Get(context.Background()); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if _, err := async.Execute(cb, context.Background(), succeed).Get(context.Background()); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+	})
+
+	t.Run("should reopen after a failed half-open trial", func(t *testing.T) {
+		cb := async.NewCircuitBreaker(1, 10*time.Millisecond)
+		_, _ = async.Execute(cb, context.Background(), fail).Get(context.Background())
+
+		time.Sleep(20 * time.Millisecond)
+		if _, err := async.Execute(cb, context.Background(), fail).Get(context.Background()); err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		if _, err := async.Execute(cb, context.Background(), succeed).Get(context.Background()); err != async.ErrCircuitOpen {
+			t.Fatalf("Expected %v, but got %v", async.ErrCircuitOpen, err)
+		}
+	})
+
+	t.Run("should allow a single half-open trial at a time", func(t *testing.T) {
+		cb := async.NewCircuitBreaker(1, 10*time.Millisecond)
+		_, _ = async.Execute(cb, context.Background(), fail).Get(context.Background())
+		time.Sleep(20 * time.Millisecond)
+
+		releaseCh := make(chan struct{})
+		trial := async.Execute(cb, context.Background(), func(ctx context.Context) (int, error) {
+			<-releaseCh
+			return 1, nil
+		})
+
+		if _, err := async.Execute(cb, context.Background(), succeed).Get(context.Background()); err != async.ErrCircuitOpen {
+			t.Fatalf("Expected %v, but got %v", async.ErrCircuitOpen, err)
+		}
+
+		close(releaseCh)
+		if _, err := trial.Get(context.Background()); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+	})
+	t.Run("should ignore calls admitted before the breaker opened", func(t *testing.T) {
+		cb := async.NewCircuitBreaker(1, 10*time.Millisecond)
+
+		releaseSlowCh := make(chan struct{})
+		slow := async.Execute(cb, context.Background(), func(ctx context.Context) (int, error) {
+			<-releaseSlowCh
+			return 1, nil
+		})
+		_, _ = async.Execute(cb, context.Background(), fail).Get(context.Background())
+		time.Sleep(20 * time.Millisecond)
+
+		releaseTrialCh := make(chan struct{})
+		trial := async.Execute(cb, context.Background(), func(ctx context.Context) (int, error) {
+			<-releaseTrialCh
+			return 0, errTest
+		})
+
+		close(releaseSlowCh)
+		_, _ = slow.Get(context.Background())
+		if _, err := async.Execute(cb, context.Background(), succeed).Get(context.Background()); err != async.ErrCircuitOpen {
+			t.Fatalf("Expected %v while the trial is in flight, but got %v", async.ErrCircuitOpen, err)
+		}
+
+		close(releaseTrialCh)
+		_, _ = trial.Get(context.Background())
+		if _, err := async.Execute(cb, context.Background(), succeed).Get(context.Background()); err != async.ErrCircuitOpen {
+			t.Fatalf("Expected %v after the failed trial, but got %v", async.ErrCircuitOpen, err)
+		}
+	})
+}
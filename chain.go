@@ -0,0 +1,269 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Map returns a Future that resolves to fn applied to the value of fut.
+// If fut fails, the error is propagated untouched and fn is never called.
+// ctx governs the wait for fut; if it's cancelled first, the returned Future
+// resolves with the context error.
+//
+// Example:
+//
+//	fut := async.Go(ctx, fetchUser)
+//	nameFut := async.Map(ctx, fut, func(u User) string {
+//		return u.Name
+//	})
+func Map[T, U any](ctx context.Context, fut Future[T], fn func(T) U) Future[U] {
+	return Go(ctx, func(ctx context.Context) (U, error) {
+		val, err := fut.Get(ctx)
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+
+		return fn(val), nil
+	})
+}
+
+// Then waits for fut and, if it succeeds, runs fn with the resolved value in a new goroutine.
+// The returned Future resolves with the result of fn, and errors from fn are returned verbatim.
+// If fut fails, fn is skipped and the error is forwarded.
+// ctx is the context used to wait for fut and is passed to fn.
+//
+// Example:
+//
+//	userFut := async.Go(ctx, fetchUser)
+//	ordersFut := async.Then(ctx, userFut, func(ctx context.Context, u User) ([]Order, error) {
+//		return fetchOrders(ctx, u.ID)
+//	})
+func Then[T, U any](ctx context.Context, fut Future[T], fn func(context.Context, T) (U, error)) Future[U] {
+	return Go(ctx, func(ctx context.Context) (U, error) {
+		val, err := fut.Get(ctx)
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+
+		return fn(ctx, val)
+	})
+}
+
+// ThenWithin is like Then but runs fn with a context expiring stageTimeout after fn starts,
+// or at the deadline inherited from ctx if that's sooner, so later stages of a chain only get the remaining budget.
+// If the budget is already blown when fut completes, fn isn't called,
+// and the returned Future resolves with context.DeadlineExceeded whenever the stage deadline is exceeded.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(ctx, time.Second) // the overall budget
+//	defer cancel()
+//
+//	userFut := async.Go(ctx, fetchUser)
+//	ordersFut := async.ThenWithin(ctx, 200*time.Millisecond, userFut, fetchOrders)
+func ThenWithin[T, U any](ctx context.Context, stageTimeout time.Duration, fut Future[T], fn func(context.Context, T) (U, error)) Future[U] {
+	return Then(ctx, fut, func(ctx context.Context, val T) (U, error) {
+		ctx, cancel := context.WithTimeout(ctx, stageTimeout)
+		defer cancel()
+
+		var res U
+		err := ctx.Err()
+		if err == nil {
+			res, err = fn(ctx, val)
+		}
+
+		if ctxErr := ctx.Err(); ctxErr == context.DeadlineExceeded {
+			var zero U
+			return zero, ctxErr
+		}
+
+		return res, err
+	})
+}
+
+// NamedThen is like Then but wraps errors returned by fn with the stage name,
+// e.g. `stage "fetch orders": not found`, to tell which stage of a pipeline failed.
+// The wrapped error still unwraps to the original one for errors.Is and errors.As.
+// Errors from fut are forwarded as is, so they keep the label of the stage that produced them.
+func NamedThen[T, U any](ctx context.Context, name string, fut Future[T], fn func(context.Context, T) (U, error)) Future[U] {
+	return Then(ctx, fut, func(ctx context.Context, val T) (U, error) {
+		res, err := fn(ctx, val)
+		if err != nil {
+			return res, fmt.Errorf("stage %q: %w", name, err)
+		}
+
+		return res, nil
+	})
+}
+
+// Tap returns a Future that passes the result of fut through unchanged after calling fn with it,
+// e.g. to log intermediate values of a pipeline. fn is called exactly once,
+// before the returned Future is done. If ctx is done first, fn sees the context error.
+func Tap[T any](ctx context.Context, fut Future[T], fn func(T, error)) Future[T] {
+	tapFut := newFuture[T]()
+
+	// not built on Go, so fn is still called when ctx is already done
+	go tapFut.run(ctx, func(ctx context.Context) (T, error) {
+		val, err := fut.Get(ctx)
+		fn(val, err)
+		return val, err
+	})
+
+	return tapFut
+}
+
+// OnComplete calls fn with the result of fut once it's done, in a separate goroutine.
+// fn is called exactly once per registration, and multiple callbacks can be registered independently.
+// If fut never completes, the goroutine stays blocked until the process exits.
+func OnComplete[T any](fut Future[T], fn func(T, error)) {
+	go func() {
+		fn(fut.Get(context.Background()))
+	}()
+}
+
+// FlatMap waits for fut and, if it succeeds, calls fn to obtain a new Future and forwards its result.
+// If fut fails, fn is skipped and the error is forwarded.
+// ctx governs the wait for both futures, so cancelling it makes the returned Future resolve with the context error.
+func FlatMap[T, U any](ctx context.Context, fut Future[T], fn func(context.Context, T) Future[U]) Future[U] {
+	return Go(ctx, func(ctx context.Context) (U, error) {
+		val, err := fut.Get(ctx)
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+
+		return fn(ctx, val).Get(ctx)
+	})
+}
+
+// Join flattens a Future of a Future: it waits for ff, then for the inner Future, and resolves with its result.
+// Errors at either level are propagated. If ff fails, there is no inner Future to wait for,
+// and if ctx is done while waiting for the inner one, the wait stops without leaking a goroutine.
+func Join[T any](ctx context.Context, ff Future[Future[T]]) Future[T] {
+	return FlatMap(ctx, ff, func(_ context.Context, fut Future[T]) Future[T] {
+		return fut
+	})
+}
+
+// Recover returns a Future that resolves with fn(err) if fut fails, or with the value of fut otherwise.
+// fn is never called if fut succeeds.
+//
+// Cancellation of ctx is not recovered: if ctx is done before fut completes,
+// the returned Future resolves with the context error and fn is not called.
+// Errors of fut itself, including context errors from the context fut was started with, are passed to fn.
+func Recover[T any](ctx context.Context, fut Future[T], fn func(error) T) Future[T] {
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		if err := waitDone(ctx, fut); err != nil {
+			var zero T
+			return zero, err
+		}
+
+		val, err := fut.Get(context.Background())
+		if err == nil {
+			return val, nil
+		}
+
+		return fn(err), nil
+	})
+}
+
+// waitDone blocks until w is done or ctx is done, returning the context error in the latter case.
+// Like Get, it favors w if both are done, so callers can tell errors of w from the cancellation of ctx.
+func waitDone(ctx context.Context, w Waitable) error {
+	select {
+	case <-w.Done():
+		return nil
+	default:
+	}
+
+	select {
+	case <-w.Done():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RecoverWith is like Recover but the fallback is asynchronous:
+// if fut fails, fn is called with the error and the result of the Future it returns becomes the final result,
+// including its errors. On success, the value of fut is forwarded and fn is never called.
+// Like Recover, cancellation of ctx is not recovered.
+func RecoverWith[T any](ctx context.Context, fut Future[T], fn func(context.Context, error) Future[T]) Future[T] {
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		if err := waitDone(ctx, fut); err != nil {
+			var zero T
+			return zero, err
+		}
+
+		val, err := fut.Get(context.Background())
+		if err == nil {
+			return val, nil
+		}
+
+		return fn(ctx, err).Get(ctx)
+	})
+}
+
+// CatchAs is like Recover but only handles errors of fut matching type E via errors.As,
+// e.g. to recover from a *NotFoundError while propagating everything else.
+// handler is called with the matched error and its result becomes the final result, including its error.
+// Other errors are passed through unchanged, and like Recover, cancellation of ctx is not recovered.
+//
+// Example:
+//
+//	fut := async.CatchAs(ctx, userFut, func(err *NotFoundError) (User, error) {
+//		return GuestUser, nil
+//	})
+func CatchAs[T any, E error](ctx context.Context, fut Future[T], handler func(E) (T, error)) Future[T] {
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		if err := waitDone(ctx, fut); err != nil {
+			var zero T
+			return zero, err
+		}
+
+		val, err := fut.Get(context.Background())
+		if err == nil {
+			return val, nil
+		}
+
+		var target E
+		if !errors.As(err, &target) {
+			return val, err
+		}
+
+		return handler(target)
+	})
+}
+
+// Bind returns a Future bound to ctx: its Done channel is closed either when fut completes or when ctx is done,
+// and its Get returns the context error in the latter case.
+// This makes Done-based selects honor ctx the same way Get does. The work of fut is not cancelled.
+func Bind[T any](ctx context.Context, fut Future[T]) Future[T] {
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		return fut.Get(ctx)
+	})
+}
+
+// ChainSteps threads the value of initial through steps in order, like nested Then calls.
+// Each step runs only after the previous one succeeds, and the first error,
+// from initial or from any step, short-circuits the chain and becomes the result.
+//
+// Example:
+//
+//	fut := async.ChainSteps(ctx, async.Go(ctx, loadDraft), validate, normalize, save)
+func ChainSteps[T any](ctx context.Context, initial Future[T], steps ...func(context.Context, T) (T, error)) Future[T] {
+	return Then(ctx, initial, func(ctx context.Context, val T) (T, error) {
+		for _, step := range steps {
+			var err error
+			if val, err = step(ctx, val); err != nil {
+				return val, err
+			}
+		}
+
+		return val, nil
+	})
+}
@@ -0,0 +1,634 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+var errTest = errors.New("test error")
+
+func TestMap(t *testing.T) {
+	t.Run("should transform the value when there is no error", func(t *testing.T) {
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			return 1, nil
+		})
+
+		mapped := async.Map(context.Background(), fut, strconv.Itoa)
+
+		resp, err := mapped.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != "1" {
+			t.Fatalf("Expected %q, but got %q", "1", resp)
+		}
+	})
+
+	t.Run("should propagate the error without calling fn", func(t *testing.T) {
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			return 0, errTest
+		})
+
+		called := false
+		mapped := async.Map(context.Background(), fut, func(v int) string {
+			called = true
+			return strconv.Itoa(v)
+		})
+
+		_, err := mapped.Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		if called {
+			t.Fatal("Expected fn not to be called")
+		}
+	})
+
+	t.Run("should return the context error when ctx is cancelled", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		mapped := async.Map(ctx, fut, strconv.Itoa)
+		cancel()
+
+		<-mapped.Done()
+		_, err := mapped.Get(context.Background())
+		if err != context.Canceled {
+			t.Fatalf("Expected %v, but got %v", context.Canceled, err)
+		}
+	})
+}
+
+func TestThen(t *testing.T) {
+	t.Run("should chain the value when there is no error", func(t *testing.T) {
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			return 1, nil
+		})
+
+		chained := async.Then(context.Background(), fut, func(ctx context.Context, v int) (string, error) {
+			return strconv.Itoa(v + 1), nil
+		})
+
+		resp, err := chained.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != "2" {
+			t.Fatalf("Expected %q, but got %q", "2", resp)
+		}
+	})
+
+	t.Run("should return the error from fn verbatim", func(t *testing.T) {
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			return 1, nil
+		})
+
+		chained := async.Then(context.Background(), fut, func(ctx context.Context, v int) (string, error) {
+			return "", errTest
+		})
+
+		_, err := chained.Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+
+	t.Run("should skip fn when fut fails", func(t *testing.T) {
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			return 0, errTest
+		})
+
+		called := false
+		chained := async.Then(context.Background(), fut, func(ctx context.Context, v int) (string, error) {
+			called = true
+			return "", nil
+		})
+
+		_, err := chained.Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		if called {
+			t.Fatal("Expected fn not to be called")
+		}
+	})
+
+	t.Run("should respect the context passed at construction", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		chained := async.Then(ctx, fut, func(ctx context.Context, v int) (string, error) {
+			return strconv.Itoa(v), nil
+		})
+		cancel()
+
+		<-chained.Done()
+		_, err := chained.Get(context.Background())
+		if err != context.Canceled {
+			t.Fatalf("Expected %v, but got %v", context.Canceled, err)
+		}
+	})
+}
+
+func TestOnComplete(t *testing.T) {
+	t.Run("should call every callback with the result", func(t *testing.T) {
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			return 1, errTest
+		})
+
+		resultCh := make(chan int, 2)
+		for i := 0; i < 2; i++ {
+			async.OnComplete(fut, func(v int, err error) {
+				if err != errTest {
+					t.Errorf("Expected %v, but got %v", errTest, err)
+				}
+
+				resultCh <- v
+			})
+		}
+
+		for i := 0; i < 2; i++ {
+			select {
+			case v := <-resultCh:
+				if v != 1 {
+					t.Fatalf("Expected 1, but got %v", v)
+				}
+			case <-time.After(100 * time.Millisecond):
+				t.Fatal("test timed out")
+			}
+		}
+	})
+}
+
+func TestFlatMap(t *testing.T) {
+	t.Run("should forward the result of the inner future", func(t *testing.T) {
+		fut := async.Resolved(1)
+
+		chained := async.FlatMap(context.Background(), fut, func(ctx context.Context, v int) async.Future[string] {
+			return async.Resolved(strconv.Itoa(v))
+		})
+
+		resp, err := chained.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != "1" {
+			t.Fatalf("Expected %q, but got %q", "1", resp)
+		}
+	})
+
+	t.Run("should skip fn when fut fails", func(t *testing.T) {
+		called := false
+		chained := async.FlatMap(context.Background(), async.Failed[int](errTest), func(ctx context.Context, v int) async.Future[string] {
+			called = true
+			return async.Resolved("")
+		})
+
+		_, err := chained.Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		if called {
+			t.Fatal("Expected fn not to be called")
+		}
+	})
+
+	t.Run("should return the context error while waiting for the inner future", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		chained := async.FlatMap(ctx, async.Resolved(1), func(ctx context.Context, v int) async.Future[string] {
+			return async.Go(context.Background(), func(ctx context.Context) (string, error) {
+				<-testEndCh
+				return "", nil
+			})
+		})
+
+		_, err := chained.Get(context.Background())
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+func TestRecover(t *testing.T) {
+	t.Run("should return the fallback value when fut fails", func(t *testing.T) {
+		recovered := async.Recover(context.Background(), async.Failed[int](errTest), func(err error) int {
+			if err != errTest {
+				t.Errorf("Expected %v, but got %v", errTest, err)
+			}
+
+			return 2
+		})
+
+		resp, err := recovered.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != 2 {
+			t.Fatalf("Expected 2, but got %v", resp)
+		}
+	})
+
+	t.Run("should pass the value through when fut succeeds", func(t *testing.T) {
+		called := false
+		recovered := async.Recover(context.Background(), async.Resolved(1), func(err error) int {
+			called = true
+			return 2
+		})
+
+		resp, err := recovered.Get(context.Background())
+		if err != nil || resp != 1 {
+			t.Fatalf("Expected 1, but got %v, %v", resp, err)
+		}
+
+		if called {
+			t.Fatal("Expected fn not to be called")
+		}
+	})
+
+	t.Run("should not recover the cancellation of ctx", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		called := false
+		recovered := async.Recover(ctx, fut, func(err error) int {
+			called = true
+			return 2
+		})
+		cancel()
+
+		_, err := recovered.Get(context.Background())
+		if err != context.Canceled {
+			t.Fatalf("Expected %v, but got %v", context.Canceled, err)
+		}
+
+		if called {
+			t.Fatal("Expected fn not to be called")
+		}
+	})
+}
+
+func TestRecoverWith(t *testing.T) {
+	t.Run("should return the result of the fallback future", func(t *testing.T) {
+		recovered := async.RecoverWith(context.Background(), async.Failed[int](errTest), func(ctx context.Context, err error) async.Future[int] {
+			return async.Resolved(2)
+		})
+
+		resp, err := recovered.Get(context.Background())
+		if err != nil || resp != 2 {
+			t.Fatalf("Expected 2, but got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("should propagate the error of the fallback future", func(t *testing.T) {
+		errFallback := errors.New("fallback error")
+		recovered := async.RecoverWith(context.Background(), async.Failed[int](errTest), func(ctx context.Context, err error) async.Future[int] {
+			return async.Failed[int](errFallback)
+		})
+
+		_, err := recovered.Get(context.Background())
+		if err != errFallback {
+			t.Fatalf("Expected %v, but got %v", errFallback, err)
+		}
+	})
+
+	t.Run("should forward the value without calling fn", func(t *testing.T) {
+		called := false
+		recovered := async.RecoverWith(context.Background(), async.Resolved(1), func(ctx context.Context, err error) async.Future[int] {
+			called = true
+			return async.Resolved(2)
+		})
+
+		resp, err := recovered.Get(context.Background())
+		if err != nil || resp != 1 {
+			t.Fatalf("Expected 1, but got %v, %v", resp, err)
+		}
+
+		if called {
+			t.Fatal("Expected fn not to be called")
+		}
+	})
+}
+
+func TestBind(t *testing.T) {
+	t.Run("should forward the result of fut", func(t *testing.T) {
+		resp, err := async.Bind(context.Background(), async.Resolved(1)).Get(context.Background())
+		if err != nil || resp != 1 {
+			t.Fatalf("Expected 1, but got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("should close Done when ctx is done", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		bound := async.Bind(ctx, fut)
+		select {
+		case <-bound.Done():
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("test timed out")
+		}
+
+		_, err := bound.Get(context.Background())
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+func TestNamedThen(t *testing.T) {
+	t.Run("should label the error with the stage name", func(t *testing.T) {
+		first := async.NamedThen(context.Background(), "parse", async.Resolved("1"), func(ctx context.Context, v string) (int, error) {
+			return strconv.Atoi(v)
+		})
+		second := async.NamedThen(context.Background(), "fetch", first, func(ctx context.Context, v int) (string, error) {
+			return "", errTest
+		})
+
+		_, err := second.Get(context.Background())
+		if err == nil || err.Error() != `stage "fetch": test error` {
+			t.Fatalf("Expected a labelled error, but got %v", err)
+		}
+
+		if !errors.Is(err, errTest) {
+			t.Fatalf("Expected %v to unwrap to %v", err, errTest)
+		}
+	})
+
+	t.Run("should keep the label of the failing stage", func(t *testing.T) {
+		first := async.NamedThen(context.Background(), "parse", async.Resolved("x"), func(ctx context.Context, v string) (int, error) {
+			return strconv.Atoi(v)
+		})
+		second := async.NamedThen(context.Background(), "fetch", first, func(ctx context.Context, v int) (string, error) {
+			return "", nil
+		})
+
+		_, err := second.Get(context.Background())
+		var numErr *strconv.NumError
+		if !errors.As(err, &numErr) {
+			t.Fatalf("Expected a *strconv.NumError, but got %v", err)
+		}
+
+		if !strings.HasPrefix(err.Error(), `stage "parse"`) {
+			t.Fatalf("Expected the parse label, but got %v", err)
+		}
+	})
+}
+
+func TestJoin(t *testing.T) {
+	t.Run("should resolve with the result of the inner future", func(t *testing.T) {
+		ff := async.Go(context.Background(), func(ctx context.Context) (async.Future[int], error) {
+			return async.Resolved(1), nil
+		})
+
+		resp, err := async.Join(context.Background(), ff).Get(context.Background())
+		if err != nil || resp != 1 {
+			t.Fatalf("Expected 1, but got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("should propagate the error of the outer future", func(t *testing.T) {
+		ff := async.Failed[async.Future[int]](errTest)
+
+		_, err := async.Join(context.Background(), ff).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+
+	t.Run("should propagate the error of the inner future", func(t *testing.T) {
+		ff := async.Resolved(async.Failed[int](errTest))
+
+		_, err := async.Join(context.Background(), ff).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+}
+
+func TestTap(t *testing.T) {
+	t.Run("should call fn with the context error when ctx is already done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+
+		calls := 0
+		var seen error
+		_, err := async.Tap(ctx, fut, func(v int, err error) {
+			calls++
+			seen = err
+		}).Get(context.Background())
+		if err != context.Canceled {
+			t.Fatalf("Expected %v, but got %v", context.Canceled, err)
+		}
+
+		if calls != 1 || seen != context.Canceled {
+			t.Fatalf("Expected fn to be called once with %v, but got %v calls with %v", context.Canceled, calls, seen)
+		}
+	})
+
+	t.Run("should call fn before passing the result through", func(t *testing.T) {
+		calls := 0
+		var seen int
+		tapped := async.Tap(context.Background(), async.Resolved(1), func(v int, err error) {
+			calls++
+			seen = v
+		})
+
+		resp, err := tapped.Get(context.Background())
+		if err != nil || resp != 1 {
+			t.Fatalf("Expected 1, but got %v, %v", resp, err)
+		}
+
+		if calls != 1 || seen != 1 {
+			t.Fatalf("Expected fn to be called once with 1, but got %v calls with %v", calls, seen)
+		}
+	})
+
+	t.Run("should pass the error through", func(t *testing.T) {
+		var seen error
+		tapped := async.Tap(context.Background(), async.Failed[int](errTest), func(v int, err error) {
+			seen = err
+		})
+
+		_, err := tapped.Get(context.Background())
+		if err != errTest || seen != errTest {
+			t.Fatalf("Expected %v, but got %v and %v", errTest, err, seen)
+		}
+	})
+}
+
+func TestChainSteps(t *testing.T) {
+	addOne := func(_ context.Context, v int) (int, error) {
+		return v + 1, nil
+	}
+
+	t.Run("should apply all steps in order", func(t *testing.T) {
+		double := func(_ context.Context, v int) (int, error) {
+			return v * 2, nil
+		}
+
+		resp, err := async.ChainSteps(context.Background(), async.Resolved(1), addOne, double, addOne).Get(context.Background())
+		if err != nil || resp != 5 {
+			t.Fatalf("Expected 5, but got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("should stop at the first failing step", func(t *testing.T) {
+		var called atomic.Bool
+		failing := func(_ context.Context, v int) (int, error) {
+			return 0, errTest
+		}
+		last := func(_ context.Context, v int) (int, error) {
+			called.Store(true)
+			return v, nil
+		}
+
+		_, err := async.ChainSteps(context.Background(), async.Resolved(1), addOne, failing, last).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		if called.Load() {
+			t.Fatalf("Expected the last step to be skipped")
+		}
+	})
+}
+
+type notFoundError struct {
+	key string
+}
+
+func (e *notFoundError) Error() string {
+	return "not found: " + e.key
+}
+
+func TestCatchAs(t *testing.T) {
+	handler := func(err *notFoundError) (string, error) {
+		return "default " + err.key, nil
+	}
+
+	t.Run("should handle errors of the matching type", func(t *testing.T) {
+		fut := async.Failed[string](fmt.Errorf("lookup: %w", &notFoundError{key: "user"}))
+
+		resp, err := async.CatchAs(context.Background(), fut, handler).Get(context.Background())
+		if err != nil || resp != "default user" {
+			t.Fatalf("Expected %q, but got %v, %v", "default user", resp, err)
+		}
+	})
+
+	t.Run("should pass other errors through", func(t *testing.T) {
+		_, err := async.CatchAs(context.Background(), async.Failed[string](errTest), handler).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+
+	t.Run("should forward the value on success", func(t *testing.T) {
+		resp, err := async.CatchAs(context.Background(), async.Resolved("value"), handler).Get(context.Background())
+		if err != nil || resp != "value" {
+			t.Fatalf("Expected %q, but got %v, %v", "value", resp, err)
+		}
+	})
+}
+
+func TestThenWithin(t *testing.T) {
+	t.Run("should give the stage the remaining overall budget", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		overall, _ := ctx.Deadline()
+
+		releaseCh := make(chan struct{})
+		first := async.Go(ctx, func(ctx context.Context) (int, error) {
+			<-releaseCh
+			return 1, nil
+		})
+
+		var stageDeadline time.Time
+		fut := async.ThenWithin(ctx, time.Minute, first, func(ctx context.Context, v int) (int, error) {
+			stageDeadline, _ = ctx.Deadline()
+			return v + 1, nil
+		})
+		close(releaseCh)
+
+		resp, err := fut.Get(context.Background())
+		if err != nil || resp != 2 {
+			t.Fatalf("Expected 2, but got %v, %v", resp, err)
+		}
+
+		if !stageDeadline.Equal(overall) {
+			t.Fatalf("Expected the stage deadline %v, but got %v", overall, stageDeadline)
+		}
+	})
+
+	t.Run("should apply the stage timeout when it's tighter", func(t *testing.T) {
+		_, err := async.ThenWithin(context.Background(), 10*time.Millisecond, async.Resolved(1), func(ctx context.Context, v int) (int, error) {
+			<-ctx.Done()
+			return 0, errTest
+		}).Get(context.Background())
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+
+	t.Run("should return the result of fn within the budget", func(t *testing.T) {
+		resp, err := async.ThenWithin(context.Background(), time.Second, async.Resolved(1), func(ctx context.Context, v int) (int, error) {
+			return v + 1, nil
+		}).Get(context.Background())
+		if err != nil || resp != 2 {
+			t.Fatalf("Expected 2, but got %v, %v", resp, err)
+		}
+	})
+}
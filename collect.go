@@ -0,0 +1,368 @@
+package async
+
+import (
+	"context"
+)
+
+// Result holds the outcome of a Future.
+// Incomplete is set when the future wasn't done in time, e.g. by GetAllWithin, in which case Value and Err are zero.
+type Result[T any] struct {
+	Value      T
+	Err        error
+	Incomplete bool
+}
+
+// indexedResult is a Result tagged with the index of its future.
+type indexedResult[T any] struct {
+	Result[T]
+	index int
+}
+
+// waitConcurrently waits for all futs concurrently and sends each result as soon as it's available.
+// The channel is buffered so that no goroutine is blocked on sending,
+// and each goroutine returns once ctx is done.
+func waitConcurrently[T any](ctx context.Context, futs []Future[T]) <-chan indexedResult[T] {
+	resultCh := make(chan indexedResult[T], len(futs))
+	for i, fut := range futs {
+		go func(i int, fut Future[T]) {
+			val, err := fut.Get(ctx)
+			resultCh <- indexedResult[T]{
+				Result: Result[T]{Value: val, Err: err},
+				index:  i,
+			}
+		}(i, fut)
+	}
+
+	return resultCh
+}
+
+// All returns a Future that resolves once all futs are done.
+// The resolved slice preserves the order of futs.
+// If any future fails, the returned Future resolves with the first error in index order and a nil slice.
+//
+// Example:
+//
+//	futs := []async.Future[int]{
+//		async.Go(ctx, fetchA),
+//		async.Go(ctx, fetchB),
+//	}
+//
+//	vals, err := async.All(ctx, futs).Get(ctx)
+func All[T any](ctx context.Context, futs []Future[T]) Future[[]T] {
+	return Go(ctx, func(ctx context.Context) ([]T, error) {
+		vals := make([]T, len(futs))
+		for i, fut := range futs {
+			val, err := fut.Get(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			vals[i] = val
+		}
+
+		return vals, nil
+	})
+}
+
+// AllCancel runs each of fns in a new goroutine with a shared cancellable context derived from ctx,
+// and returns a Future of their values in the order of fns, like errgroup in a single call.
+// On the first error in completion order, the shared context is cancelled so cooperative siblings can stop,
+// and the returned Future resolves with that error right away, without waiting for the siblings to return.
+//
+// Example:
+//
+//	pages, err := async.AllCancel(ctx, fetchHeader, fetchBody, fetchFooter).Get(ctx)
+func AllCancel[T any](ctx context.Context, fns ...func(context.Context) (T, error)) Future[[]T] {
+	return Go(ctx, func(ctx context.Context) ([]T, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		futs := make([]Future[T], len(fns))
+		for i, fn := range fns {
+			futs[i] = Go(ctx, fn)
+		}
+
+		resultCh := waitConcurrently(ctx, futs)
+		vals := make([]T, len(futs))
+		for range futs {
+			r := <-resultCh
+			if r.Err != nil {
+				return nil, r.Err
+			}
+
+			vals[r.index] = r.Value
+		}
+
+		return vals, nil
+	})
+}
+
+// AllSettled returns a Future that resolves once all futs are done, regardless of failures.
+// The resolved slice preserves the order of futs and each Result holds the value or the error of the corresponding future.
+// The returned Future never fails due to an input failure.
+// If ctx is cancelled, the context error is captured in the Result of each future that hasn't finished yet.
+func AllSettled[T any](ctx context.Context, futs []Future[T]) Future[[]Result[T]] {
+	settledFut := newFuture[[]Result[T]]()
+
+	// not built on Go, so the results are still collected when ctx is already done
+	go settledFut.run(ctx, func(ctx context.Context) ([]Result[T], error) {
+		results := make([]Result[T], len(futs))
+		for i, fut := range futs {
+			results[i].Value, results[i].Err = fut.Get(ctx)
+		}
+
+		return results, nil
+	})
+
+	return settledFut
+}
+
+// Any returns a Future that resolves with the value of whichever future in futs succeeds first.
+// If all futures fail, it resolves with an *AggregateError holding all errors in index order.
+// If futs is empty, it resolves with ErrNoFutures.
+func Any[T any](ctx context.Context, futs []Future[T]) Future[T] {
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		var zero T
+		if len(futs) == 0 {
+			return zero, ErrNoFutures
+		}
+
+		// stop waiting for the remaining futures once a value is found
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		resultCh := waitConcurrently(ctx, futs)
+		errs := make([]error, len(futs))
+		for range futs {
+			r := <-resultCh
+			if r.Err == nil {
+				return r.Value, nil
+			}
+
+			errs[r.index] = r.Err
+		}
+
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+
+		return zero, &AggregateError{Errors: errs}
+	})
+}
+
+// Race returns a Future that resolves with the result of whichever future in futs finishes first,
+// whether it's a value or an error.
+// The remaining futures are not cancelled and are left to complete on their own.
+// If futs is empty, it resolves with ErrNoFutures.
+func Race[T any](ctx context.Context, futs []Future[T]) Future[T] {
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		if len(futs) == 0 {
+			var zero T
+			return zero, ErrNoFutures
+		}
+
+		// stop waiting for the remaining futures once the race is decided
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		r := <-waitConcurrently(ctx, futs)
+		return r.Value, r.Err
+	})
+}
+
+// WaitAll blocks until all ws are done or ctx is done.
+// It returns the context error in the latter case and nil otherwise.
+// The results of ws are not inspected, call Get on each future to access them.
+//
+// Example:
+//
+//	userFut := async.Go(ctx, fetchUser)
+//	ordersFut := async.Go(ctx, fetchOrders)
+//
+//	if err := async.WaitAll(ctx, userFut, ordersFut); err != nil {
+//		return err
+//	}
+func WaitAll(ctx context.Context, ws ...Waitable) error {
+	for _, w := range ws {
+		select {
+		case <-w.Done():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// Reduce returns a Future that folds the values of futs into initial using fn, in index order.
+// The futures are awaited concurrently and each value is folded as soon as all the values before it are available.
+// If any future fails, the returned Future resolves with the first error as soon as it's detected.
+func Reduce[T, U any](ctx context.Context, futs []Future[T], initial U, fn func(U, T) U) Future[U] {
+	return Go(ctx, func(ctx context.Context) (U, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		resultCh := waitConcurrently(ctx, futs)
+		pending := make(map[int]T, len(futs))
+		acc, next := initial, 0
+		for range futs {
+			r := <-resultCh
+			if r.Err != nil {
+				var zero U
+				return zero, r.Err
+			}
+
+			pending[r.index] = r.Value
+			for val, ok := pending[next]; ok; val, ok = pending[next] {
+				delete(pending, next)
+				acc = fn(acc, val)
+				next++
+			}
+		}
+
+		return acc, nil
+	})
+}
+
+// FirstN returns a Future that resolves with the values of the first n futures in futs to succeed, in completion order.
+// Once it becomes impossible to reach n successes, it resolves with an *AggregateError holding the errors so far in index order.
+// If n is greater than the number of futures, it resolves with ErrNotEnoughFutures.
+// Results are consumed one at a time, so when more than n futures succeed at nearly the same time,
+// exactly n values are kept and the rest is ignored. The remaining futures are left running.
+func FirstN[T any](ctx context.Context, n int, futs []Future[T]) Future[[]T] {
+	return Go(ctx, func(ctx context.Context) ([]T, error) {
+		if n > len(futs) {
+			return nil, ErrNotEnoughFutures
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		resultCh := waitConcurrently(ctx, futs)
+		vals := make([]T, 0, max(n, 0))
+		errs := make([]error, len(futs))
+		failures := 0
+		for len(vals) < n {
+			r := <-resultCh
+			if r.Err == nil {
+				vals = append(vals, r.Value)
+				continue
+			}
+
+			errs[r.index] = r.Err
+			failures++
+			if len(futs)-failures < n {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+
+				return nil, &AggregateError{Errors: compactErrors(errs)}
+			}
+		}
+
+		return vals, nil
+	})
+}
+
+// AllErrors waits for all futs and returns their values in the order of futs, along with every failure.
+// If any future fails, the error is an *AggregateError holding the errors of the failed futures in index order,
+// so errors.Is and errors.As match any of them, and the slots of the failed futures hold zero values.
+// If ctx is done first, the futures not done yet are reported as failed with the context error.
+//
+// Example:
+//
+//	vals, err := async.AllErrors(ctx, futs)
+//	var aggErr *async.AggregateError
+//	if errors.As(err, &aggErr) {
+//		log.Printf("%d of %d calls failed", len(aggErr.Errors), len(futs))
+//	}
+func AllErrors[T any](ctx context.Context, futs []Future[T]) ([]T, error) {
+	vals := make([]T, len(futs))
+	errs := make([]error, len(futs))
+	for i, fut := range futs {
+		vals[i], errs[i] = fut.Get(ctx)
+	}
+
+	if errs = compactErrors(errs); len(errs) > 0 {
+		return vals, &AggregateError{Errors: errs}
+	}
+
+	return vals, nil
+}
+
+// compactErrors returns the non-nil errors of errs, keeping their order.
+func compactErrors(errs []error) []error {
+	compacted := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if err != nil {
+			compacted = append(compacted, err)
+		}
+	}
+
+	return compacted
+}
+
+// WaitUntil returns a Future that feeds the results of futs to cond in completion order as they arrive,
+// and resolves with all the results seen so far once cond returns true.
+// If all futures are done and cond never held, it resolves with ErrConditionNotMet.
+// If ctx is done first, it resolves with the context error.
+//
+// Example:
+//
+//	// wait until at least 3 health checks pass
+//	fut := async.WaitUntil(ctx, checks, func(results []async.Result[bool]) bool {
+//		passed := 0
+//		for _, r := range results {
+//			if r.Err == nil && r.Value {
+//				passed++
+//			}
+//		}
+//		return passed >= 3
+//	})
+func WaitUntil[T any](ctx context.Context, futs []Future[T], cond func(results []Result[T]) bool) Future[[]Result[T]] {
+	return Go(ctx, func(ctx context.Context) ([]Result[T], error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		resultCh := waitConcurrently(ctx, futs)
+		results := make([]Result[T], 0, len(futs))
+		for range futs {
+			select {
+			case r := <-resultCh:
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+
+				results = append(results, r.Result)
+				if cond(results) {
+					return results, nil
+				}
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		return nil, ErrConditionNotMet
+	})
+}
+
+// MergeResults returns a Future that awaits all the maps produced by futs and merges them into one.
+// Maps are merged in index order, so when several maps hold the same key, the value from the later future wins.
+// If any future fails, the returned Future resolves with the first error in index order.
+//
+// Example:
+//
+//	// values from the environment override the ones from the config file
+//	cfg, err := async.MergeResults(ctx, []async.Future[map[string]string]{fileFut, envFut}).Get(ctx)
+func MergeResults[K comparable, V any](ctx context.Context, futs []Future[map[K]V]) Future[map[K]V] {
+	return Then(ctx, All(ctx, futs), func(_ context.Context, maps []map[K]V) (map[K]V, error) {
+		merged := make(map[K]V)
+		for _, m := range maps {
+			for k, v := range m {
+				merged[k] = v
+			}
+		}
+
+		return merged, nil
+	})
+}
@@ -0,0 +1,583 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+func TestAll(t *testing.T) {
+	t.Run("should return all values in order", func(t *testing.T) {
+		futs := []async.Future[int]{
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				time.Sleep(10 * time.Millisecond)
+				return 1, nil
+			}),
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				return 2, nil
+			}),
+		}
+
+		resp, err := async.All(context.Background(), futs).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(resp) != 2 || resp[0] != 1 || resp[1] != 2 {
+			t.Fatalf("Expected [1 2], but got %v", resp)
+		}
+	})
+
+	t.Run("should return the first error in index order", func(t *testing.T) {
+		errOther := errors.New("other error")
+		futs := []async.Future[int]{
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				return 1, nil
+			}),
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				time.Sleep(10 * time.Millisecond)
+				return 0, errTest
+			}),
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				return 0, errOther
+			}),
+		}
+
+		resp, err := async.All(context.Background(), futs).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		if resp != nil {
+			t.Fatalf("Expected a nil slice, but got %v", resp)
+		}
+	})
+
+	t.Run("should respect the deadline when a future never finishes", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		futs := []async.Future[int]{
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				<-testEndCh
+				return 1, nil
+			}),
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := async.All(context.Background(), futs).Get(ctx)
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+func TestAllCancel(t *testing.T) {
+	t.Run("should return the values in order", func(t *testing.T) {
+		resp, err := async.AllCancel(context.Background(),
+			func(ctx context.Context) (int, error) {
+				time.Sleep(5 * time.Millisecond)
+				return 1, nil
+			},
+			func(ctx context.Context) (int, error) {
+				return 2, nil
+			},
+		).Get(context.Background())
+
+		if err != nil || len(resp) != 2 || resp[0] != 1 || resp[1] != 2 {
+			t.Fatalf("Expected [1 2], but got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("should cancel siblings on the first error", func(t *testing.T) {
+		siblingErrCh := make(chan error, 1)
+
+		_, err := async.AllCancel(context.Background(),
+			func(ctx context.Context) (int, error) {
+				<-ctx.Done()
+				siblingErrCh <- ctx.Err()
+				return 0, ctx.Err()
+			},
+			func(ctx context.Context) (int, error) {
+				return 0, errTest
+			},
+		).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		select {
+		case siblingErr := <-siblingErrCh:
+			if siblingErr != context.Canceled {
+				t.Fatalf("Expected %v, but got %v", context.Canceled, siblingErr)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected the sibling to observe cancellation")
+		}
+	})
+}
+
+func TestAllSettled(t *testing.T) {
+	t.Run("should record the context error for pending futures when ctx is already done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		pending := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 2, nil
+		})
+
+		results, err := async.AllSettled(ctx, []async.Future[int]{async.Resolved(1), pending}).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(results) != 2 || results[0].Value != 1 || results[1].Err != context.Canceled {
+			t.Fatalf("Expected the value of the first future and the context error for the second, but got %v", results)
+		}
+	})
+
+	t.Run("should return all results in order", func(t *testing.T) {
+		futs := []async.Future[int]{
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				time.Sleep(10 * time.Millisecond)
+				return 1, nil
+			}),
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				return 0, errTest
+			}),
+		}
+
+		resp, err := async.AllSettled(context.Background(), futs).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(resp) != 2 {
+			t.Fatalf("Expected 2 results, but got %v", len(resp))
+		}
+
+		if resp[0].Value != 1 || resp[0].Err != nil {
+			t.Fatalf("Expected {1 <nil>}, but got %v", resp[0])
+		}
+
+		if resp[1].Err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, resp[1].Err)
+		}
+	})
+
+	t.Run("should capture the context error in the result", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		futs := []async.Future[int]{
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				return 1, nil
+			}),
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				<-testEndCh
+				return 2, nil
+			}),
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		resp, err := async.AllSettled(ctx, futs).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp[1].Err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, resp[1].Err)
+		}
+	})
+}
+
+func TestAny(t *testing.T) {
+	t.Run("should return the first successful value", func(t *testing.T) {
+		futs := []async.Future[int]{
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				return 0, errTest
+			}),
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				time.Sleep(10 * time.Millisecond)
+				return 2, nil
+			}),
+		}
+
+		resp, err := async.Any(context.Background(), futs).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != 2 {
+			t.Fatalf("Expected 2, but got %v", resp)
+		}
+	})
+
+	t.Run("should aggregate errors when all futures fail", func(t *testing.T) {
+		errOther := errors.New("other error")
+		futs := []async.Future[int]{
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				return 0, errTest
+			}),
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				return 0, errOther
+			}),
+		}
+
+		_, err := async.Any(context.Background(), futs).Get(context.Background())
+		if !errors.Is(err, errTest) || !errors.Is(err, errOther) {
+			t.Fatalf("Expected an aggregated error, but got %v", err)
+		}
+	})
+
+	t.Run("should return ErrNoFutures when there is no future", func(t *testing.T) {
+		_, err := async.Any[int](context.Background(), nil).Get(context.Background())
+		if err != async.ErrNoFutures {
+			t.Fatalf("Expected %v, but got %v", async.ErrNoFutures, err)
+		}
+	})
+}
+
+func TestRace(t *testing.T) {
+	t.Run("should return the result of the first finished future", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		futs := []async.Future[int]{
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				<-testEndCh
+				return 1, nil
+			}),
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				return 0, errTest
+			}),
+		}
+
+		_, err := async.Race(context.Background(), futs).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+
+	t.Run("should return the context error when no future finishes", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		futs := []async.Future[int]{
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				<-testEndCh
+				return 1, nil
+			}),
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := async.Race(ctx, futs).Get(context.Background())
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+func TestWaitAll(t *testing.T) {
+	t.Run("should wait for futures of different types", func(t *testing.T) {
+		a := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			time.Sleep(10 * time.Millisecond)
+			return 1, nil
+		})
+		b := async.Go(context.Background(), func(ctx context.Context) (string, error) {
+			return "", errTest
+		})
+
+		if err := async.WaitAll(context.Background(), a, b); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		select {
+		case <-a.Done():
+		default:
+			t.Fatal("Expected the future to be done")
+		}
+	})
+
+	t.Run("should return the context error when ctx is done", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		a := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if err := async.WaitAll(ctx, a); err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+func TestReduce(t *testing.T) {
+	t.Run("should fold values in index order", func(t *testing.T) {
+		futs := []async.Future[string]{
+			async.Go(context.Background(), func(ctx context.Context) (string, error) {
+				time.Sleep(10 * time.Millisecond)
+				return "a", nil
+			}),
+			async.Resolved("b"),
+			async.Resolved("c"),
+		}
+
+		resp, err := async.Reduce(context.Background(), futs, ">", func(acc string, v string) string {
+			return acc + v
+		}).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != ">abc" {
+			t.Fatalf("Expected %q, but got %q", ">abc", resp)
+		}
+	})
+
+	t.Run("should fail without waiting for a slow early future", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		futs := []async.Future[int]{
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				<-testEndCh
+				return 1, nil
+			}),
+			async.Failed[int](errTest),
+		}
+
+		_, err := async.Reduce(context.Background(), futs, 0, func(acc int, v int) int {
+			return acc + v
+		}).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+}
+
+func TestFirstN(t *testing.T) {
+	t.Run("should return the first n values in completion order", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		futs := []async.Future[int]{
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				<-testEndCh
+				return 1, nil
+			}),
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				time.Sleep(10 * time.Millisecond)
+				return 2, nil
+			}),
+			async.Failed[int](errTest),
+			async.Resolved(4),
+		}
+
+		resp, err := async.FirstN(context.Background(), 2, futs).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(resp) != 2 || resp[0] != 4 || resp[1] != 2 {
+			t.Fatalf("Expected [4 2], but got %v", resp)
+		}
+	})
+
+	t.Run("should fail once n successes are impossible", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		errOther := errors.New("other error")
+		futs := []async.Future[int]{
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				<-testEndCh
+				return 1, nil
+			}),
+			async.Failed[int](errTest),
+			async.Failed[int](errOther),
+		}
+
+		_, err := async.FirstN(context.Background(), 2, futs).Get(context.Background())
+		if !errors.Is(err, errTest) || !errors.Is(err, errOther) {
+			t.Fatalf("Expected an aggregated error, but got %v", err)
+		}
+	})
+
+	t.Run("should return exactly n values", func(t *testing.T) {
+		futs := make([]async.Future[int], 10)
+		for i := range futs {
+			futs[i] = async.Resolved(i)
+		}
+
+		resp, err := async.FirstN(context.Background(), 3, futs).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(resp) != 3 {
+			t.Fatalf("Expected 3 values, but got %v", resp)
+		}
+	})
+
+	t.Run("should return ErrNotEnoughFutures when n is too large", func(t *testing.T) {
+		_, err := async.FirstN(context.Background(), 2, []async.Future[int]{async.Resolved(1)}).Get(context.Background())
+		if err != async.ErrNotEnoughFutures {
+			t.Fatalf("Expected %v, but got %v", async.ErrNotEnoughFutures, err)
+		}
+	})
+}
+
+func TestWaitUntil(t *testing.T) {
+	atLeast := func(n int) func([]async.Result[bool]) bool {
+		return func(results []async.Result[bool]) bool {
+			passed := 0
+			for _, r := range results {
+				if r.Err == nil && r.Value {
+					passed++
+				}
+			}
+
+			return passed >= n
+		}
+	}
+
+	t.Run("should resolve once the condition holds", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		futs := []async.Future[bool]{
+			async.Resolved(true),
+			async.Failed[bool](errTest),
+			async.Resolved(true),
+			async.Go(context.Background(), func(ctx context.Context) (bool, error) {
+				<-testEndCh
+				return true, nil
+			}),
+		}
+
+		resp, err := async.WaitUntil(context.Background(), futs, atLeast(2)).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(resp) < 2 || len(resp) > 3 {
+			t.Fatalf("Expected the results seen so far, but got %v", resp)
+		}
+	})
+
+	t.Run("should return ErrConditionNotMet when the condition never holds", func(t *testing.T) {
+		futs := []async.Future[bool]{
+			async.Resolved(true),
+			async.Resolved(false),
+		}
+
+		_, err := async.WaitUntil(context.Background(), futs, atLeast(2)).Get(context.Background())
+		if err != async.ErrConditionNotMet {
+			t.Fatalf("Expected %v, but got %v", async.ErrConditionNotMet, err)
+		}
+	})
+
+	t.Run("should return the context error when ctx is done", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		futs := []async.Future[bool]{
+			async.Go(context.Background(), func(ctx context.Context) (bool, error) {
+				<-testEndCh
+				return true, nil
+			}),
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := async.WaitUntil(ctx, futs, atLeast(1)).Get(context.Background())
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+func TestMergeResults(t *testing.T) {
+	t.Run("should let later futures override earlier keys", func(t *testing.T) {
+		futs := []async.Future[map[string]int]{
+			async.Go(context.Background(), func(ctx context.Context) (map[string]int, error) {
+				time.Sleep(10 * time.Millisecond)
+				return map[string]int{"a": 1, "b": 1}, nil
+			}),
+			async.Resolved(map[string]int{"b": 2, "c": 2}),
+		}
+
+		resp, err := async.MergeResults(context.Background(), futs).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(resp) != 3 || resp["a"] != 1 || resp["b"] != 2 || resp["c"] != 2 {
+			t.Fatalf("Expected map[a:1 b:2 c:2], but got %v", resp)
+		}
+	})
+
+	t.Run("should return the first error", func(t *testing.T) {
+		futs := []async.Future[map[string]int]{
+			async.Resolved(map[string]int{"a": 1}),
+			async.Failed[map[string]int](errTest),
+		}
+
+		_, err := async.MergeResults(context.Background(), futs).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+}
+
+func TestAllErrors(t *testing.T) {
+	t.Run("should return all values when there is no error", func(t *testing.T) {
+		vals, err := async.AllErrors(context.Background(), []async.Future[int]{async.Resolved(1), async.Resolved(2)})
+		if err != nil || len(vals) != 2 || vals[0] != 1 || vals[1] != 2 {
+			t.Fatalf("Expected [1 2], but got %v, %v", vals, err)
+		}
+	})
+
+	t.Run("should return every failure along with the successful values", func(t *testing.T) {
+		errOther := errors.New("other error")
+		futs := []async.Future[int]{
+			async.Failed[int](errTest),
+			async.Resolved(2),
+			async.Failed[int](errOther),
+		}
+
+		vals, err := async.AllErrors(context.Background(), futs)
+		if !errors.Is(err, errTest) || !errors.Is(err, errOther) {
+			t.Fatalf("Expected %v to match both errors", err)
+		}
+
+		var aggErr *async.AggregateError
+		if !errors.As(err, &aggErr) || len(aggErr.Errors) != 2 {
+			t.Fatalf("Expected an AggregateError with 2 errors, but got %v", err)
+		}
+
+		if len(vals) != 3 || vals[0] != 0 || vals[1] != 2 || vals[2] != 0 {
+			t.Fatalf("Expected [0 2 0], but got %v", vals)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Debouncer coalesces rapid triggers into a single delayed execution of a function.
+type Debouncer[T any] struct {
+	d  time.Duration
+	fn func(context.Context) (T, error)
+
+	mu   sync.Mutex
+	call *debounceCall[T]
+}
+
+// debounceCall is a pending execution shared by the triggers of a window.
+type debounceCall[T any] struct {
+	fut   *futureImpl[T]
+	ctx   context.Context
+	timer *time.Timer
+}
+
+// NewDebouncer creates a Debouncer that runs fn once no trigger has arrived for d.
+func NewDebouncer[T any](d time.Duration, fn func(context.Context) (T, error)) *Debouncer[T] {
+	return &Debouncer[T]{
+		d:  d,
+		fn: fn,
+	}
+}
+
+// Trigger schedules an execution of fn after the debounce duration and returns a Future of its result.
+// Triggers arriving before the execution starts reset the timer and share the same Future,
+// and fn runs with the context of the latest of them.
+// A trigger arriving while a previous execution is already running schedules a new execution.
+func (db *Debouncer[T]) Trigger(ctx context.Context) Future[T] {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if c := db.call; c != nil && c.timer.Stop() {
+		c.ctx = ctx
+		c.timer.Reset(db.d)
+		return c.fut
+	}
+
+	c := &debounceCall[T]{
+		fut: newFuture[T](),
+		ctx: ctx,
+	}
+	c.timer = time.AfterFunc(db.d, func() {
+		db.fire(c)
+	})
+	db.call = c
+
+	return c.fut
+}
+
+// fire runs fn for c once its window has passed.
+func (db *Debouncer[T]) fire(c *debounceCall[T]) {
+	db.mu.Lock()
+	if db.call == c {
+		db.call = nil
+	}
+	ctx := c.ctx
+	db.mu.Unlock()
+
+	c.fut.run(ctx, db.fn)
+}
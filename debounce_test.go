@@ -0,0 +1,67 @@
+package async_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+func TestDebouncer(t *testing.T) {
+	t.Run("should coalesce rapid triggers into one execution", func(t *testing.T) {
+		var calls int32
+		db := async.NewDebouncer(50*time.Millisecond, func(ctx context.Context) (int32, error) {
+			return atomic.AddInt32(&calls, 1), nil
+		})
+
+		futs := make([]async.Future[int32], 5)
+		for i := range futs {
+			futs[i] = db.Trigger(context.Background())
+			time.Sleep(time.Millisecond)
+		}
+
+		for _, fut := range futs {
+			resp, err := fut.Get(context.Background())
+			if err != nil || resp != 1 {
+				t.Fatalf("Expected 1, but got %v, %v", resp, err)
+			}
+		}
+
+		if atomic.LoadInt32(&calls) != 1 {
+			t.Fatalf("Expected 1 call, but got %v", calls)
+		}
+	})
+
+	t.Run("should schedule a new execution while one is running", func(t *testing.T) {
+		var calls int32
+		releaseCh := make(chan struct{})
+		db := async.NewDebouncer(time.Millisecond, func(ctx context.Context) (int32, error) {
+			n := atomic.AddInt32(&calls, 1)
+			if n == 1 {
+				<-releaseCh
+			}
+
+			return n, nil
+		})
+
+		first := db.Trigger(context.Background())
+		for atomic.LoadInt32(&calls) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+
+		second := db.Trigger(context.Background())
+		close(releaseCh)
+
+		resp, err := first.Get(context.Background())
+		if err != nil || resp != 1 {
+			t.Fatalf("Expected 1, but got %v, %v", resp, err)
+		}
+
+		resp, err = second.Get(context.Background())
+		if err != nil || resp != 2 {
+			t.Fatalf("Expected 2, but got %v, %v", resp, err)
+		}
+	})
+}
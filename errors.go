@@ -0,0 +1,82 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+// ErrNoFutures is returned when an operation requires at least one future but none is provided.
+var ErrNoFutures = errors.New("async: no futures")
+
+// ErrNotEnoughFutures is returned when an operation requires more futures than provided.
+var ErrNotEnoughFutures = errors.New("async: not enough futures")
+
+// ErrConditionNotMet is returned by WaitUntil when all futures are done but the condition never held.
+var ErrConditionNotMet = errors.New("async: condition not met")
+
+// AggregateError holds multiple errors returned by a group of futures.
+// It implements Unwrap() []error so errors.Is and errors.As can match any of the errors.
+type AggregateError struct {
+	Errors []error
+}
+
+// Error returns the messages of all errors, separated by a semicolon.
+func (e *AggregateError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+
+	return "async: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors held by e.
+func (e *AggregateError) Unwrap() []error {
+	return e.Errors
+}
+
+// PanicError is returned when the function of a future panics.
+// It carries the recovered value and the stack trace of the panicking goroutine.
+// ID is the identity of the task for futures created by GoID, and empty otherwise.
+type PanicError struct {
+	Value any
+	Stack []byte
+	ID    string
+}
+
+// Error returns the recovered value as a string, prefixed by the task ID if any.
+func (e *PanicError) Error() string {
+	if e.ID != "" {
+		return fmt.Sprintf("async: panic in task %q: %v", e.ID, e.Value)
+	}
+
+	return fmt.Sprintf("async: panic: %v", e.Value)
+}
+
+// Unwrap returns the recovered value if it's an error, otherwise nil.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// newPanicError creates a *PanicError from a recovered value, capturing the current stack trace.
+func newPanicError(r any) *PanicError {
+	return &PanicError{
+		Value: r,
+		Stack: debug.Stack(),
+	}
+}
+
+// safeCall calls fn and converts a panic into a *PanicError.
+func safeCall[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) (val T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = newPanicError(r)
+		}
+	}()
+
+	return fn(ctx)
+}
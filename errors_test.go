@@ -0,0 +1,24 @@
+package async_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bongnv/async"
+)
+
+func TestAggregateError(t *testing.T) {
+	t.Run("should match any of the errors", func(t *testing.T) {
+		errOther := errors.New("other error")
+		var err error = &async.AggregateError{Errors: []error{errTest, errOther}}
+
+		if !errors.Is(err, errTest) || !errors.Is(err, errOther) {
+			t.Fatalf("Expected %v to match all errors", err)
+		}
+
+		expected := "async: test error; other error"
+		if err.Error() != expected {
+			t.Fatalf("Expected %q, but got %q", expected, err.Error())
+		}
+	})
+}
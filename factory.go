@@ -0,0 +1,49 @@
+package async
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Factory starts futures and keeps track of how many of them are running.
+type Factory struct {
+	inFlight atomic.Int64
+	started  atomic.Uint64
+}
+
+// NewFactory creates a Factory.
+//
+// Example:
+//
+//	factory := async.NewFactory()
+//	fut := async.GoFromFactory(ctx, factory, someWorkFn)
+//
+//	log.Printf("running: %d", factory.InFlight())
+func NewFactory() *Factory {
+	return &Factory{}
+}
+
+// GoFromFactory is like Go but counts the future in the metrics of f.
+// The counters are updated even if fn panics.
+func GoFromFactory[T any](ctx context.Context, f *Factory, fn func(ctx context.Context) (T, error)) Future[T] {
+	f.started.Add(1)
+	f.inFlight.Add(1)
+
+	fut := newFuture[T]()
+	go fut.run(ctx, func(ctx context.Context) (T, error) {
+		defer f.inFlight.Add(-1)
+		return fn(ctx)
+	})
+
+	return fut
+}
+
+// InFlight returns the number of futures started by f that are still running.
+func (f *Factory) InFlight() int {
+	return int(f.inFlight.Load())
+}
+
+// TotalStarted returns the number of futures started by f so far.
+func (f *Factory) TotalStarted() uint64 {
+	return f.started.Load()
+}
@@ -0,0 +1,67 @@
+package async_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bongnv/async"
+)
+
+func TestFactory(t *testing.T) {
+	t.Run("should count running and started futures", func(t *testing.T) {
+		factory := async.NewFactory()
+		releaseCh := make(chan struct{})
+		startedCh := make(chan struct{}, 2)
+
+		futs := make([]async.Future[int], 2)
+		for i := range futs {
+			futs[i] = async.GoFromFactory(context.Background(), factory, func(ctx context.Context) (int, error) {
+				startedCh <- struct{}{}
+				<-releaseCh
+				return 1, nil
+			})
+		}
+
+		<-startedCh
+		<-startedCh
+		if factory.InFlight() != 2 {
+			t.Fatalf("Expected 2 in-flight futures, but got %v", factory.InFlight())
+		}
+
+		close(releaseCh)
+		_, _ = async.All(context.Background(), futs).Get(context.Background())
+
+		if factory.InFlight() != 0 {
+			t.Fatalf("Expected no in-flight future, but got %v", factory.InFlight())
+		}
+
+		if factory.TotalStarted() != 2 {
+			t.Fatalf("Expected 2 started futures, but got %v", factory.TotalStarted())
+		}
+	})
+
+	t.Run("should update the counters on panic", func(t *testing.T) {
+		factory := async.NewFactory()
+
+		_, _ = async.GoFromFactory(context.Background(), factory, func(ctx context.Context) (int, error) {
+			panic("boom")
+		}).Get(context.Background())
+
+		if factory.InFlight() != 0 {
+			t.Fatalf("Expected no in-flight future, but got %v", factory.InFlight())
+		}
+	})
+	t.Run("should update the counters when the context is already cancelled", func(t *testing.T) {
+		factory := async.NewFactory()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, _ = async.GoFromFactory(ctx, factory, func(ctx context.Context) (int, error) {
+			return 1, nil
+		}).Get(context.Background())
+
+		if factory.InFlight() != 0 {
+			t.Fatalf("Expected no in-flight future, but got %v", factory.InFlight())
+		}
+	})
+}
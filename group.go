@@ -0,0 +1,67 @@
+package async
+
+import (
+	"context"
+	"sync"
+)
+
+// Group coordinates related futures with a shared context that's cancelled on the first error.
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	errOnce sync.Once
+	err     error
+}
+
+// NewGroup creates a Group and returns it along with its context derived from ctx.
+// The context is cancelled once any member fails or Wait returns.
+//
+// Example:
+//
+//	g, ctx := async.NewGroup(ctx)
+//	userFut := async.GoInGroup(g, fetchUser)
+//	ordersFut := async.GoInGroup(g, fetchOrders)
+//
+//	if err := g.Wait(); err != nil {
+//		return err
+//	}
+func NewGroup(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{
+		ctx:    ctx,
+		cancel: cancel,
+	}, ctx
+}
+
+// GoInGroup runs fn with the context of g in a different goroutine and returns a Future of its result.
+// If fn fails, the context of g is cancelled so sibling members can stop.
+func GoInGroup[T any](g *Group, fn func(ctx context.Context) (T, error)) Future[T] {
+	g.wg.Add(1)
+
+	fut := newFuture[T]()
+	go fut.run(g.ctx, func(ctx context.Context) (T, error) {
+		defer g.wg.Done()
+
+		// fut.call keeps a panic of fn reported by Panicked while recording it as the group error
+		val, err := fut.call(ctx, fn)
+		if err != nil {
+			g.errOnce.Do(func() {
+				g.err = err
+				g.cancel()
+			})
+		}
+
+		return val, err
+	})
+
+	return fut
+}
+
+// Wait blocks until all members of g are done and returns the first error, if any.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+	return g.err
+}
@@ -0,0 +1,78 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+func TestGroup(t *testing.T) {
+	t.Run("should wait for all members", func(t *testing.T) {
+		g, _ := async.NewGroup(context.Background())
+
+		a := async.GoInGroup(g, func(ctx context.Context) (int, error) {
+			time.Sleep(10 * time.Millisecond)
+			return 1, nil
+		})
+		b := async.GoInGroup(g, func(ctx context.Context) (string, error) {
+			return "b", nil
+		})
+
+		if err := g.Wait(); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp, _ := a.Get(context.Background()); resp != 1 {
+			t.Fatalf("Expected 1, but got %v", resp)
+		}
+
+		if resp, _ := b.Get(context.Background()); resp != "b" {
+			t.Fatalf("Expected %q, but got %q", "b", resp)
+		}
+	})
+
+	t.Run("should cancel siblings on the first error", func(t *testing.T) {
+		g, groupCtx := async.NewGroup(context.Background())
+
+		sibling := async.GoInGroup(g, func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+		_ = async.GoInGroup(g, func(ctx context.Context) (int, error) {
+			return 0, errTest
+		})
+
+		if err := g.Wait(); err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		if _, err := sibling.Get(context.Background()); err != context.Canceled {
+			t.Fatalf("Expected %v, but got %v", context.Canceled, err)
+		}
+
+		if groupCtx.Err() != context.Canceled {
+			t.Fatalf("Expected the group context to be cancelled, but got %v", groupCtx.Err())
+		}
+	})
+
+	t.Run("should report a panicking member", func(t *testing.T) {
+		g, _ := async.NewGroup(context.Background())
+
+		fut := async.GoInGroup(g, func(ctx context.Context) (int, error) {
+			panic("boom")
+		})
+
+		var panicErr *async.PanicError
+		if err := g.Wait(); !errors.As(err, &panicErr) {
+			t.Fatalf("Expected a PanicError, but got %v", err)
+		}
+
+		<-fut.Done()
+		if !async.Panicked(fut) {
+			t.Fatal("Expected the future to be panicked")
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package async
+
+import (
+	"sync/atomic"
+)
+
+// Holder holds a Future that can be swapped atomically, e.g. for a cache refreshed periodically.
+// Readers always get a consistent Future: a call to Store doesn't affect those that already loaded the previous one.
+// The zero value is an empty Holder ready to use. It's safe for concurrent use.
+//
+// Example:
+//
+//	var config async.Holder[Config]
+//	config.Store(async.Go(ctx, loadConfig))
+//
+//	// on refresh
+//	config.Store(async.Go(ctx, loadConfig))
+//
+//	cfg, err := config.Load().Get(ctx)
+type Holder[T any] struct {
+	fut atomic.Pointer[Future[T]]
+}
+
+// Load returns the current Future of h, or nil if nothing has been stored yet.
+func (h *Holder[T]) Load() Future[T] {
+	if fut := h.fut.Load(); fut != nil {
+		return *fut
+	}
+
+	return nil
+}
+
+// Store replaces the current Future of h with fut.
+func (h *Holder[T]) Store(fut Future[T]) {
+	h.fut.Store(&fut)
+}
@@ -0,0 +1,55 @@
+package async_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/bongnv/async"
+)
+
+func TestHolder(t *testing.T) {
+	t.Run("should return nil when empty", func(t *testing.T) {
+		var h async.Holder[int]
+		if fut := h.Load(); fut != nil {
+			t.Fatalf("Expected nil, but got %v", fut)
+		}
+	})
+
+	t.Run("should return the latest stored future", func(t *testing.T) {
+		var h async.Holder[int]
+		old := async.Resolved(1)
+		h.Store(old)
+		h.Store(async.Resolved(2))
+
+		if resp, _ := h.Load().Get(context.Background()); resp != 2 {
+			t.Fatalf("Expected 2, but got %v", resp)
+		}
+
+		if resp, _ := old.Get(context.Background()); resp != 1 {
+			t.Fatalf("Expected the old future to be unaffected, but got %v", resp)
+		}
+	})
+
+	t.Run("should be safe for concurrent loads and stores", func(t *testing.T) {
+		var h async.Holder[int]
+		h.Store(async.Resolved(0))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer wg.Done()
+				h.Store(async.Resolved(i))
+			}(i)
+			go func() {
+				defer wg.Done()
+				if resp, err := h.Load().Get(context.Background()); err != nil || resp < 0 || resp >= 10 {
+					t.Errorf("Expected a stored value, but got %v, %v", resp, err)
+				}
+			}()
+		}
+
+		wg.Wait()
+	})
+}
@@ -0,0 +1,48 @@
+package async
+
+import (
+	"context"
+	"sync"
+)
+
+// Lazy returns a Future that runs fn only when its result is first needed,
+// i.e. on the first call to Get or Done. All calls share the same single execution.
+//
+// fn runs with the context of the first Get that triggers the execution,
+// so cancelling it also cancels the work for later callers.
+// If the execution is triggered by Done, fn runs with context.Background().
+func Lazy[T any](fn func(context.Context) (T, error)) Future[T] {
+	return &lazyFuture[T]{
+		fn:  fn,
+		fut: newFuture[T](),
+	}
+}
+
+// lazyFuture is an implementation of Future that defers fn until it's needed.
+type lazyFuture[T any] struct {
+	once sync.Once
+	fn   func(context.Context) (T, error)
+	fut  *futureImpl[T]
+}
+
+func (l *lazyFuture[T]) Done() <-chan struct{} {
+	l.start(context.Background())
+	return l.fut.Done()
+}
+
+func (l *lazyFuture[T]) Get(ctx context.Context) (T, error) {
+	l.start(ctx)
+	return l.fut.Get(ctx)
+}
+
+// Panicked reports whether l is done because fn panicked.
+func (l *lazyFuture[T]) Panicked() bool {
+	return l.fut.Panicked()
+}
+
+// start runs fn in a different goroutine the first time it's called.
+func (l *lazyFuture[T]) start(ctx context.Context) {
+	l.once.Do(func() {
+		go l.fut.run(ctx, l.fn)
+	})
+}
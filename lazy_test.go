@@ -0,0 +1,63 @@
+package async_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+type ctxKey struct{}
+
+func TestLazy(t *testing.T) {
+	t.Run("should not run fn until Get is called", func(t *testing.T) {
+		var calls int32
+		fut := async.Lazy(func(ctx context.Context) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			return 1, nil
+		})
+
+		time.Sleep(10 * time.Millisecond)
+		if atomic.LoadInt32(&calls) != 0 {
+			t.Fatal("Expected fn not to be called before Get")
+		}
+
+		for i := 0; i < 2; i++ {
+			resp, err := fut.Get(context.Background())
+			if err != nil || resp != 1 {
+				t.Fatalf("Expected 1, but got %v, %v", resp, err)
+			}
+		}
+
+		if calls != 1 {
+			t.Fatalf("Expected 1 call, but got %v", calls)
+		}
+	})
+
+	t.Run("should run fn when Done is called", func(t *testing.T) {
+		fut := async.Lazy(func(ctx context.Context) (int, error) {
+			return 1, nil
+		})
+
+		select {
+		case <-fut.Done():
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("test timed out")
+		}
+	})
+
+	t.Run("should use the context of the first Get", func(t *testing.T) {
+		fut := async.Lazy(func(ctx context.Context) (string, error) {
+			v, _ := ctx.Value(ctxKey{}).(string)
+			return v, nil
+		})
+
+		ctx := context.WithValue(context.Background(), ctxKey{}, "first")
+		resp, err := fut.Get(ctx)
+		if err != nil || resp != "first" {
+			t.Fatalf("Expected %q, but got %q, %v", "first", resp, err)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrManagerClosed is returned by futures started on a Manager that is shut down.
+var ErrManagerClosed = errors.New("async: manager closed")
+
+// ShutdownError is returned by Manager.Shutdown when its context is done before all futures finish.
+type ShutdownError struct {
+	// Remaining is the number of futures still running.
+	Remaining int
+	// Err is the context error.
+	Err error
+}
+
+// Error returns the number of remaining futures along with the context error.
+func (e *ShutdownError) Error() string {
+	return fmt.Sprintf("async: shutdown with %d futures still running: %v", e.Remaining, e.Err)
+}
+
+// Unwrap returns the context error.
+func (e *ShutdownError) Unwrap() error {
+	return e.Err
+}
+
+// Manager tracks the futures it starts so they can be drained on shutdown.
+type Manager struct {
+	mu      sync.Mutex
+	running int
+	closed  bool
+	idleCh  chan struct{}
+}
+
+// NewManager creates a Manager.
+//
+// Example:
+//
+//	m := async.NewManager()
+//	fut := async.GoManaged(ctx, m, someWorkFn)
+//
+//	// on shutdown
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	err := m.Shutdown(ctx)
+func NewManager() *Manager {
+	return &Manager{
+		idleCh: make(chan struct{}),
+	}
+}
+
+// GoManaged is like Go but tracks the future in m.
+// If m is shut down, the returned Future fails with ErrManagerClosed.
+func GoManaged[T any](ctx context.Context, m *Manager, fn func(ctx context.Context) (T, error)) Future[T] {
+	if err := ctx.Err(); err != nil {
+		return Failed[T](err)
+	}
+
+	if !m.add() {
+		return Failed[T](ErrManagerClosed)
+	}
+
+	fut := newFuture[T]()
+
+	go func() {
+		defer m.done()
+		fut.run(ctx, fn)
+	}()
+
+	return fut
+}
+
+// Shutdown stops accepting new work and waits for all futures started by m to finish.
+// If ctx is done first, it returns a *ShutdownError carrying the number of futures still running.
+// It's safe to call Shutdown multiple times.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	if !m.closed {
+		m.closed = true
+		if m.running == 0 {
+			close(m.idleCh)
+		}
+	}
+	m.mu.Unlock()
+
+	select {
+	case <-m.idleCh:
+		return nil
+	case <-ctx.Done():
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		return &ShutdownError{
+			Remaining: m.running,
+			Err:       ctx.Err(),
+		}
+	}
+}
+
+// add registers a new future and returns false if m is shut down.
+func (m *Manager) add() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return false
+	}
+
+	m.running++
+	return true
+}
+
+// done unregisters a finished future.
+func (m *Manager) done() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.running--
+	if m.closed && m.running == 0 {
+		close(m.idleCh)
+	}
+}
@@ -0,0 +1,95 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+func TestManager(t *testing.T) {
+	t.Run("should wait for outstanding futures on Shutdown", func(t *testing.T) {
+		m := async.NewManager()
+
+		fut := async.GoManaged(context.Background(), m, func(ctx context.Context) (int, error) {
+			time.Sleep(10 * time.Millisecond)
+			return 1, nil
+		})
+
+		if err := m.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		select {
+		case <-fut.Done():
+		default:
+			t.Fatal("Expected the future to be done after Shutdown")
+		}
+
+		if err := m.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+	})
+
+	t.Run("should reject new work after Shutdown", func(t *testing.T) {
+		m := async.NewManager()
+		_ = m.Shutdown(context.Background())
+
+		_, err := async.GoManaged(context.Background(), m, func(ctx context.Context) (int, error) {
+			return 1, nil
+		}).Get(context.Background())
+		if err != async.ErrManagerClosed {
+			t.Fatalf("Expected %v, but got %v", async.ErrManagerClosed, err)
+		}
+	})
+
+	t.Run("should not track fn when ctx is already done", func(t *testing.T) {
+		m := async.NewManager()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := async.GoManaged(ctx, m, func(ctx context.Context) (int, error) {
+			return 1, nil
+		}).Get(context.Background())
+		if err != context.Canceled {
+			t.Fatalf("Expected %v, but got %v", context.Canceled, err)
+		}
+
+		if err := m.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+	})
+
+	t.Run("should return the number of remaining futures on timeout", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		m := async.NewManager()
+		for i := 0; i < 2; i++ {
+			_ = async.GoManaged(context.Background(), m, func(ctx context.Context) (int, error) {
+				<-testEndCh
+				return 1, nil
+			})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := m.Shutdown(ctx)
+		var shutdownErr *async.ShutdownError
+		if !errors.As(err, &shutdownErr) {
+			t.Fatalf("Expected a ShutdownError, but got %v", err)
+		}
+
+		if shutdownErr.Remaining != 2 {
+			t.Fatalf("Expected 2 remaining futures, but got %v", shutdownErr.Remaining)
+		}
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Expected %v to unwrap to %v", err, context.DeadlineExceeded)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package async
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoizeOption configures Memoize.
+type MemoizeOption func(*memoizeConfig)
+
+type memoizeConfig struct {
+	cacheErrors bool
+}
+
+// CacheErrors controls whether failed results are cached by Memoize.
+// By default, a failed result is evicted so the next call with the same key runs fn again.
+func CacheErrors(enabled bool) MemoizeOption {
+	return func(cfg *memoizeConfig) {
+		cfg.cacheErrors = enabled
+	}
+}
+
+// Memoize returns a function that runs fn asynchronously at most once per key.
+// Concurrent calls with the same key share one underlying Future and completed results are cached for subsequent calls.
+// fn runs with the context of the first call for a key, so cancelling that context affects every caller sharing the Future.
+// It's safe to call the returned function from multiple goroutines.
+//
+// Example:
+//
+//	getUser := async.Memoize(fetchUser)
+//	fut := getUser(ctx, userID)
+func Memoize[K comparable, T any](fn func(context.Context, K) (T, error), opts ...MemoizeOption) func(context.Context, K) Future[T] {
+	cfg := &memoizeConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	var mu sync.Mutex
+	cache := make(map[K]Future[T])
+
+	return func(ctx context.Context, key K) Future[T] {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if fut, ok := cache[key]; ok {
+			return fut
+		}
+
+		fut := newFuture[T]()
+		cache[key] = fut
+
+		go fut.run(ctx, func(ctx context.Context) (T, error) {
+			// deferred so a failed result is evicted before fut is done, even if fn panics
+			succeeded := false
+			defer func() {
+				if !succeeded && !cfg.cacheErrors {
+					mu.Lock()
+					delete(cache, key)
+					mu.Unlock()
+				}
+			}()
+
+			val, err := fn(ctx, key)
+			succeeded = err == nil
+			return val, err
+		})
+
+		return fut
+	}
+}
+
+// SingleFlight returns a function that shares one in-flight execution of fn among concurrent calls.
+// Results are never cached: once the execution completes, the next call runs fn again.
+// fn runs with the context of the call that started the execution.
+// It's safe to call the returned function from multiple goroutines.
+func SingleFlight[T any](fn func(context.Context) (T, error)) func(context.Context) Future[T] {
+	var (
+		mu      sync.Mutex
+		current *futureImpl[T]
+	)
+
+	return func(ctx context.Context) Future[T] {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if current != nil {
+			return current
+		}
+
+		fut := newFuture[T]()
+		current = fut
+
+		go fut.run(ctx, func(ctx context.Context) (T, error) {
+			// deferred so the next call runs fn again once fut is done, even if fn panics
+			defer func() {
+				mu.Lock()
+				current = nil
+				mu.Unlock()
+			}()
+
+			return fn(ctx)
+		})
+
+		return fut
+	}
+}
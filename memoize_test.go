@@ -0,0 +1,160 @@
+package async_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bongnv/async"
+)
+
+func TestMemoize(t *testing.T) {
+	t.Run("should share one execution per key", func(t *testing.T) {
+		var calls int32
+		startCh := make(chan struct{})
+		memoized := async.Memoize(func(ctx context.Context, key int) (int, error) {
+			<-startCh
+			atomic.AddInt32(&calls, 1)
+			return key * 2, nil
+		})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				key := i % 10
+				resp, err := memoized(context.Background(), key).Get(context.Background())
+				if err != nil || resp != key*2 {
+					t.Errorf("Expected %v, but got %v, %v", key*2, resp, err)
+				}
+			}(i)
+		}
+
+		close(startCh)
+		wg.Wait()
+
+		_, _ = memoized(context.Background(), 1).Get(context.Background())
+		if calls != 10 {
+			t.Fatalf("Expected 10 calls, but got %v", calls)
+		}
+	})
+
+	t.Run("should not cache errors by default", func(t *testing.T) {
+		calls := 0
+		memoized := async.Memoize(func(ctx context.Context, key int) (int, error) {
+			calls++
+			return 0, errTest
+		})
+
+		for i := 0; i < 2; i++ {
+			_, err := memoized(context.Background(), 1).Get(context.Background())
+			if err != errTest {
+				t.Fatalf("Expected %v, but got %v", errTest, err)
+			}
+		}
+
+		if calls != 2 {
+			t.Fatalf("Expected 2 calls, but got %v", calls)
+		}
+	})
+
+	t.Run("should cache errors when enabled", func(t *testing.T) {
+		calls := 0
+		memoized := async.Memoize(func(ctx context.Context, key int) (int, error) {
+			calls++
+			return 0, errTest
+		}, async.CacheErrors(true))
+
+		for i := 0; i < 2; i++ {
+			_, err := memoized(context.Background(), 1).Get(context.Background())
+			if err != errTest {
+				t.Fatalf("Expected %v, but got %v", errTest, err)
+			}
+		}
+
+		if calls != 1 {
+			t.Fatalf("Expected 1 call, but got %v", calls)
+		}
+	})
+
+	t.Run("should report a panic and evict it", func(t *testing.T) {
+		calls := 0
+		memoized := async.Memoize(func(ctx context.Context, key int) (int, error) {
+			calls++
+			panic("boom")
+		})
+
+		for i := 0; i < 2; i++ {
+			fut := memoized(context.Background(), 1)
+			<-fut.Done()
+			if !async.Panicked(fut) {
+				t.Fatal("Expected the future to be panicked")
+			}
+		}
+
+		if calls != 2 {
+			t.Fatalf("Expected 2 calls, but got %v", calls)
+		}
+	})
+}
+
+func TestSingleFlight(t *testing.T) {
+	t.Run("should share the in-flight execution", func(t *testing.T) {
+		var calls int32
+		releaseCh := make(chan struct{})
+		refresh := async.SingleFlight(func(ctx context.Context) (int32, error) {
+			<-releaseCh
+			return atomic.AddInt32(&calls, 1), nil
+		})
+
+		futs := make([]async.Future[int32], 10)
+		for i := range futs {
+			futs[i] = refresh(context.Background())
+		}
+
+		close(releaseCh)
+
+		for _, fut := range futs {
+			resp, err := fut.Get(context.Background())
+			if err != nil || resp != 1 {
+				t.Fatalf("Expected 1, but got %v, %v", resp, err)
+			}
+		}
+	})
+
+	t.Run("should run again after completion", func(t *testing.T) {
+		var calls int32
+		refresh := async.SingleFlight(func(ctx context.Context) (int32, error) {
+			return atomic.AddInt32(&calls, 1), nil
+		})
+
+		for i := int32(1); i <= 3; i++ {
+			resp, err := refresh(context.Background()).Get(context.Background())
+			if err != nil || resp != i {
+				t.Fatalf("Expected %v, but got %v, %v", i, resp, err)
+			}
+		}
+	})
+
+	t.Run("should report a panic and run again", func(t *testing.T) {
+		var calls int32
+		refresh := async.SingleFlight(func(ctx context.Context) (int32, error) {
+			atomic.AddInt32(&calls, 1)
+			panic("boom")
+		})
+
+		for i := 0; i < 2; i++ {
+			fut := refresh(context.Background())
+			<-fut.Done()
+			if !async.Panicked(fut) {
+				t.Fatal("Expected the future to be panicked")
+			}
+		}
+
+		if calls != 2 {
+			t.Fatalf("Expected 2 calls, but got %v", calls)
+		}
+	})
+}
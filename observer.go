@@ -0,0 +1,31 @@
+package async
+
+import (
+	"context"
+)
+
+// Observer is notified of the lifecycle events of a future.
+type Observer interface {
+	// OnStart is called right before fn starts.
+	OnStart()
+	// OnComplete is called once fn returns, with the final error or nil on success.
+	OnComplete(err error)
+}
+
+// GoObserved is like Go but notifies obs when fn starts and completes.
+// OnComplete is called before the future is done, so its effects are visible once Get returns.
+// A panic in fn is reported to OnComplete as a *PanicError.
+// No internal lock is held while calling obs.
+func GoObserved[T any](ctx context.Context, obs Observer, fn func(ctx context.Context) (T, error)) Future[T] {
+	fut := newFuture[T]()
+
+	// not built on Go, so obs is still notified when ctx is already done
+	go fut.run(ctx, func(ctx context.Context) (T, error) {
+		obs.OnStart()
+		val, err := fut.call(ctx, fn)
+		obs.OnComplete(err)
+		return val, err
+	})
+
+	return fut
+}
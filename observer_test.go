@@ -0,0 +1,91 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/bongnv/async"
+)
+
+type recordingObserver struct {
+	mu     sync.Mutex
+	events []string
+	err    error
+}
+
+func (o *recordingObserver) OnStart() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, "start")
+}
+
+func (o *recordingObserver) OnComplete(err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, "complete")
+	o.err = err
+}
+
+func TestGoObserved(t *testing.T) {
+	t.Run("should notify the observer when ctx is already done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		obs := &recordingObserver{}
+		_, err := async.GoObserved(ctx, obs, func(ctx context.Context) (int, error) {
+			return 0, ctx.Err()
+		}).Get(context.Background())
+		if err != context.Canceled {
+			t.Fatalf("Expected %v, but got %v", context.Canceled, err)
+		}
+
+		if len(obs.events) != 2 || obs.err != context.Canceled {
+			t.Fatalf("Expected start and complete with %v, but got %v with %v", context.Canceled, obs.events, obs.err)
+		}
+	})
+
+	t.Run("should notify the observer in order", func(t *testing.T) {
+		obs := &recordingObserver{}
+
+		_, err := async.GoObserved(context.Background(), obs, func(ctx context.Context) (int, error) {
+			return 0, errTest
+		}).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		if len(obs.events) != 2 || obs.events[0] != "start" || obs.events[1] != "complete" {
+			t.Fatalf("Expected [start complete], but got %v", obs.events)
+		}
+
+		if obs.err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, obs.err)
+		}
+	})
+
+	t.Run("should report a panic as a PanicError", func(t *testing.T) {
+		obs := &recordingObserver{}
+
+		_, _ = async.GoObserved(context.Background(), obs, func(ctx context.Context) (int, error) {
+			panic("boom")
+		}).Get(context.Background())
+
+		var panicErr *async.PanicError
+		if !errors.As(obs.err, &panicErr) {
+			t.Fatalf("Expected a PanicError, but got %v", obs.err)
+		}
+	})
+
+	t.Run("should be reported as panicked", func(t *testing.T) {
+		fut := async.GoObserved(context.Background(), &recordingObserver{}, func(ctx context.Context) (int, error) {
+			panic("boom")
+		})
+
+		<-fut.Done()
+		if !async.Panicked(fut) {
+			t.Fatal("Expected the future to be panicked")
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package async
+
+import (
+	"context"
+)
+
+// Pipeline is a reusable chain of stages turning an input I into an output O.
+// It's built once with Compose and can be run concurrently for many inputs.
+// Since it's a plain function, it can also be passed directly to helpers like MapSlice.
+type Pipeline[I, O any] func(ctx context.Context, input I) (O, error)
+
+// Compose returns a Pipeline that runs first, then second with its output.
+// If first fails, second is skipped and the error is returned.
+// Pipelines can be composed further to add more stages.
+//
+// Example:
+//
+//	p := async.Compose(async.Compose(parse, validate), store)
+//	fut := p.Run(ctx, input)
+func Compose[A, B, C any](first func(context.Context, A) (B, error), second func(context.Context, B) (C, error)) Pipeline[A, C] {
+	return func(ctx context.Context, input A) (C, error) {
+		val, err := first(ctx, input)
+		if err != nil {
+			var zero C
+			return zero, err
+		}
+
+		return second(ctx, val)
+	}
+}
+
+// Run runs p with input in a different goroutine and returns a Future of the output.
+func (p Pipeline[I, O]) Run(ctx context.Context, input I) Future[O] {
+	return Go(ctx, func(ctx context.Context) (O, error) {
+		return p(ctx, input)
+	})
+}
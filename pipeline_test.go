@@ -0,0 +1,66 @@
+package async_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/bongnv/async"
+)
+
+func TestPipeline(t *testing.T) {
+	parse := func(ctx context.Context, v string) (int, error) {
+		return strconv.Atoi(v)
+	}
+	double := func(ctx context.Context, v int) (int, error) {
+		return v * 2, nil
+	}
+	format := func(ctx context.Context, v int) (string, error) {
+		return "#" + strconv.Itoa(v), nil
+	}
+
+	t.Run("should run all stages in order", func(t *testing.T) {
+		p := async.Compose(async.Compose(parse, double), format)
+
+		resp, err := p.Run(context.Background(), "21").Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != "#42" {
+			t.Fatalf("Expected %q, but got %q", "#42", resp)
+		}
+	})
+
+	t.Run("should skip the remaining stages on error", func(t *testing.T) {
+		called := false
+		p := async.Compose(func(ctx context.Context, v int) (int, error) {
+			return 0, errTest
+		}, func(ctx context.Context, v int) (int, error) {
+			called = true
+			return v, nil
+		})
+
+		_, err := p.Run(context.Background(), 1).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		if called {
+			t.Fatal("Expected the second stage not to be called")
+		}
+	})
+
+	t.Run("should be reusable over a slice with MapSlice", func(t *testing.T) {
+		p := async.Compose(double, format)
+
+		resp, err := async.MapSlice(context.Background(), []int{1, 2, 3}, p).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(resp) != 3 || resp[0] != "#2" || resp[1] != "#4" || resp[2] != "#6" {
+			t.Fatalf("Expected [#2 #4 #6], but got %v", resp)
+		}
+	})
+}
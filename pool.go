@@ -0,0 +1,183 @@
+package async
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrPoolClosed is returned by futures submitted to a closed Pool.
+var ErrPoolClosed = errors.New("async: pool closed")
+
+// Pool runs submitted functions on a bounded number of worker goroutines.
+// Functions are queued until a worker is available, and queued functions with a higher priority run first.
+type Pool struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queue  taskQueue
+	seq    uint64
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewPool creates a Pool with size worker goroutines.
+// size <= 0 is treated as a single worker.
+func NewPool(size int) *Pool {
+	p := &Pool{}
+	p.cond = sync.NewCond(&p.mu)
+
+	for i := 0; i < max(size, 1); i++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+
+	return p
+}
+
+// Submit queues fn to run on one of the workers of p and returns a Future of its result.
+// Like Go, if ctx is already done, fn isn't queued and the returned Future fails with the context error.
+// Otherwise, if p is closed, the returned Future fails with ErrPoolClosed.
+// fn still runs if ctx is done while it waits in the queue, so it should check ctx.
+//
+// Example:
+//
+//	pool := async.NewPool(10)
+//	defer pool.Close()
+//
+//	fut := async.Submit(pool, ctx, someWorkFn)
+func Submit[T any](p *Pool, ctx context.Context, fn func(ctx context.Context) (T, error)) Future[T] { //nolint:revive // the pool first reads naturally as Submit(pool, ctx, fn)
+	return SubmitWithPriority(p, ctx, 0, fn)
+}
+
+// SubmitWithPriority is like Submit but queues fn with the given priority.
+// When a worker frees up, it picks the queued function with the highest priority,
+// and functions with equal priorities run in the order they were submitted.
+// Submit uses priority 0. Functions already running are never preempted.
+func SubmitWithPriority[T any](p *Pool, ctx context.Context, priority int, fn func(ctx context.Context) (T, error)) Future[T] { //nolint:revive // mirrors the argument order of Submit
+	if err := ctx.Err(); err != nil {
+		return Failed[T](err)
+	}
+
+	fut := newFuture[T]()
+	task := func() {
+		fut.run(ctx, fn)
+	}
+
+	if !p.push(priority, task) {
+		return Failed[T](ErrPoolClosed)
+	}
+
+	return fut
+}
+
+// SubmitWithTimeout is like Submit but applies timeout from the moment fn starts running on a worker,
+// so the time spent waiting in the queue doesn't count. fn runs with a context that expires after timeout,
+// and the returned Future resolves with context.DeadlineExceeded if the execution exceeds it.
+func SubmitWithTimeout[T any](p *Pool, ctx context.Context, timeout time.Duration, fn func(ctx context.Context) (T, error)) Future[T] { //nolint:revive // mirrors the argument order of Submit
+	return Submit(p, ctx, func(ctx context.Context) (T, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		val, err := fn(ctx)
+		if ctxErr := ctx.Err(); ctxErr == context.DeadlineExceeded {
+			var zero T
+			return zero, ctxErr
+		}
+
+		return val, err
+	})
+}
+
+// Close stops accepting new work and waits for all submitted functions to finish.
+// It's safe to call Close multiple times.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	p.closed = true
+	p.cond.Broadcast()
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
+
+// push queues task with priority and returns false if p is closed.
+func (p *Pool) push(priority int, task func()) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return false
+	}
+
+	p.seq++
+	heap.Push(&p.queue, queuedTask{fn: task, priority: priority, seq: p.seq})
+	p.cond.Signal()
+	return true
+}
+
+// pop waits for a queued task and returns false once p is closed and there is no task left.
+func (p *Pool) pop() (func(), bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for len(p.queue) == 0 {
+		if p.closed {
+			return nil, false
+		}
+
+		p.cond.Wait()
+	}
+
+	task := heap.Pop(&p.queue).(queuedTask)
+	return task.fn, true
+}
+
+// work runs queued tasks until p is closed.
+func (p *Pool) work() {
+	defer p.wg.Done()
+
+	for {
+		task, ok := p.pop()
+		if !ok {
+			return
+		}
+
+		task()
+	}
+}
+
+// queuedTask is a function waiting in the queue of a Pool.
+// seq records the submission order to keep equal priorities FIFO.
+type queuedTask struct {
+	fn       func()
+	priority int
+	seq      uint64
+}
+
+// taskQueue implements heap.Interface, ordering tasks by descending priority, then by submission order.
+// It's protected by the mutex of the Pool owning it.
+type taskQueue []queuedTask
+
+func (q taskQueue) Len() int { return len(q) }
+
+func (q taskQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+
+	return q[i].seq < q[j].seq
+}
+
+func (q taskQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *taskQueue) Push(x any) { *q = append(*q, x.(queuedTask)) }
+
+func (q *taskQueue) Pop() any {
+	old := *q
+	n := len(old)
+	task := old[n-1]
+	old[n-1] = queuedTask{}
+	*q = old[:n-1]
+	return task
+}
@@ -0,0 +1,214 @@
+package async_test
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+func TestPool(t *testing.T) {
+	t.Run("should run all submitted functions", func(t *testing.T) {
+		pool := async.NewPool(2)
+		defer pool.Close()
+
+		futs := make([]async.Future[int], 10)
+		for i := range futs {
+			i := i
+			futs[i] = async.Submit(pool, context.Background(), func(ctx context.Context) (int, error) {
+				return i, nil
+			})
+		}
+
+		for i, fut := range futs {
+			resp, err := fut.Get(context.Background())
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+
+			if resp != i {
+				t.Fatalf("Expected %v, but got %v", i, resp)
+			}
+		}
+	})
+
+	t.Run("should not run more than size functions at once", func(t *testing.T) {
+		pool := async.NewPool(2)
+		defer pool.Close()
+
+		var running, maxRunning int32
+		futs := make([]async.Future[int], 10)
+		for i := range futs {
+			futs[i] = async.Submit(pool, context.Background(), func(ctx context.Context) (int, error) {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					m := atomic.LoadInt32(&maxRunning)
+					if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+						break
+					}
+				}
+
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				return 0, nil
+			})
+		}
+
+		_, _ = async.All(context.Background(), futs).Get(context.Background())
+		if maxRunning > 2 {
+			t.Fatalf("Expected at most 2 running functions, but got %v", maxRunning)
+		}
+	})
+
+	t.Run("should wait for in-flight functions on Close", func(t *testing.T) {
+		pool := async.NewPool(1)
+
+		fut := async.Submit(pool, context.Background(), func(ctx context.Context) (int, error) {
+			time.Sleep(10 * time.Millisecond)
+			return 1, nil
+		})
+
+		pool.Close()
+
+		select {
+		case <-fut.Done():
+		default:
+			t.Fatal("Expected the future to be done after Close")
+		}
+	})
+
+	t.Run("should fail with ErrPoolClosed after Close", func(t *testing.T) {
+		pool := async.NewPool(1)
+		pool.Close()
+
+		_, err := async.Submit(pool, context.Background(), func(ctx context.Context) (int, error) {
+			return 1, nil
+		}).Get(context.Background())
+		if err != async.ErrPoolClosed {
+			t.Fatalf("Expected %v, but got %v", async.ErrPoolClosed, err)
+		}
+	})
+}
+
+func TestSubmit(t *testing.T) {
+	t.Run("should not queue fn when ctx is already done", func(t *testing.T) {
+		pool := async.NewPool(1)
+		defer pool.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var called atomic.Bool
+		_, err := async.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+			called.Store(true)
+			return 0, nil
+		}).Get(context.Background())
+		if err != context.Canceled {
+			t.Fatalf("Expected %v, but got %v", context.Canceled, err)
+		}
+
+		pool.Close()
+		if called.Load() {
+			t.Fatal("Expected fn not to be called")
+		}
+	})
+}
+
+func TestSubmitWithTimeout(t *testing.T) {
+	t.Run("should not count the time spent in the queue", func(t *testing.T) {
+		pool := async.NewPool(1)
+		defer pool.Close()
+
+		_ = async.Submit(pool, context.Background(), func(ctx context.Context) (int, error) {
+			time.Sleep(30 * time.Millisecond)
+			return 0, nil
+		})
+
+		resp, err := async.SubmitWithTimeout(pool, context.Background(), 20*time.Millisecond, func(ctx context.Context) (int, error) {
+			time.Sleep(time.Millisecond)
+			return 1, nil
+		}).Get(context.Background())
+		if err != nil || resp != 1 {
+			t.Fatalf("Expected 1, but got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("should fail when the execution exceeds the timeout", func(t *testing.T) {
+		pool := async.NewPool(1)
+		defer pool.Close()
+
+		_ = async.Submit(pool, context.Background(), func(ctx context.Context) (int, error) {
+			time.Sleep(10 * time.Millisecond)
+			return 0, nil
+		})
+
+		_, err := async.SubmitWithTimeout(pool, context.Background(), 10*time.Millisecond, func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 1, nil
+		}).Get(context.Background())
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+func TestSubmitWithPriority(t *testing.T) {
+	t.Run("should run higher priorities first and keep equal priorities FIFO", func(t *testing.T) {
+		pool := async.NewPool(1)
+		defer pool.Close()
+
+		startedCh := make(chan struct{})
+		releaseCh := make(chan struct{})
+		async.Submit(pool, context.Background(), func(ctx context.Context) (int, error) {
+			close(startedCh)
+			<-releaseCh
+			return 0, nil
+		})
+		<-startedCh
+
+		var mu sync.Mutex
+		var order []string
+		submit := func(name string, priority int) async.Future[int] {
+			return async.SubmitWithPriority(pool, context.Background(), priority, func(ctx context.Context) (int, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				order = append(order, name)
+				return 0, nil
+			})
+		}
+
+		futs := []async.Future[int]{
+			submit("low-1", 0),
+			submit("high-1", 10),
+			submit("low-2", 0),
+			submit("high-2", 10),
+			submit("mid", 5),
+		}
+		close(releaseCh)
+
+		for _, fut := range futs {
+			_, _ = fut.Get(context.Background())
+		}
+
+		expected := []string{"high-1", "high-2", "mid", "low-1", "low-2"}
+		if !reflect.DeepEqual(order, expected) {
+			t.Fatalf("Expected %v, but got %v", expected, order)
+		}
+	})
+
+	t.Run("should fail with ErrPoolClosed after Close", func(t *testing.T) {
+		pool := async.NewPool(1)
+		pool.Close()
+
+		_, err := async.SubmitWithPriority(pool, context.Background(), 1, func(ctx context.Context) (int, error) {
+			return 1, nil
+		}).Get(context.Background())
+		if err != async.ErrPoolClosed {
+			t.Fatalf("Expected %v, but got %v", async.ErrPoolClosed, err)
+		}
+	})
+}
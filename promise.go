@@ -0,0 +1,54 @@
+package async
+
+import (
+	"context"
+)
+
+// Promise is a Future with fluent methods for same-type pipelines, e.g.
+//
+//	total, err := async.Run(ctx, fetchPrice).
+//		Map(ctx, applyDiscount).
+//		Then(ctx, addShipping).
+//		Recover(ctx, func(err error) float64 { return 0 }).
+//		Get(ctx)
+//
+// Since methods can't have type parameters, transforms to a different type
+// remain free functions like Map and Then, which accept a Promise as a Future.
+type Promise[T any] struct {
+	fut Future[T]
+}
+
+// Run is like Go but returns a Promise.
+func Run[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) *Promise[T] {
+	return &Promise[T]{fut: Go(ctx, fn)}
+}
+
+// Get waits for the work to be done and returns the result, like Future.Get.
+func (p *Promise[T]) Get(ctx context.Context) (T, error) {
+	return p.fut.Get(ctx)
+}
+
+// Done returns a channel that's closed when the work is done, like Future.Done.
+func (p *Promise[T]) Done() <-chan struct{} {
+	return p.fut.Done()
+}
+
+// Panicked reports whether the work is done because its function panicked, like the Panicked function.
+func (p *Promise[T]) Panicked() bool {
+	return Panicked(p.fut)
+}
+
+// Map returns a Promise resolving to fn applied to the value of p. See the Map function.
+func (p *Promise[T]) Map(ctx context.Context, fn func(T) T) *Promise[T] {
+	return &Promise[T]{fut: Map(ctx, p.fut, fn)}
+}
+
+// Then returns a Promise resolving to the result of fn run with the value of p. See the Then function.
+func (p *Promise[T]) Then(ctx context.Context, fn func(context.Context, T) (T, error)) *Promise[T] {
+	return &Promise[T]{fut: Then(ctx, p.fut, fn)}
+}
+
+// Recover returns a Promise resolving to fn(err) if p fails. See the Recover function.
+func (p *Promise[T]) Recover(ctx context.Context, fn func(error) T) *Promise[T] {
+	return &Promise[T]{fut: Recover(ctx, p.fut, fn)}
+}
@@ -0,0 +1,67 @@
+package async_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/bongnv/async"
+)
+
+var _ async.Future[int] = (*async.Promise[int])(nil)
+
+func TestPromise(t *testing.T) {
+	t.Run("should chain same-type transforms", func(t *testing.T) {
+		resp, err := async.Run(context.Background(), func(ctx context.Context) (int, error) {
+			return 1, nil
+		}).
+			Map(context.Background(), func(v int) int { return v + 1 }).
+			Then(context.Background(), func(ctx context.Context, v int) (int, error) { return v * 10, nil }).
+			Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != 20 {
+			t.Fatalf("Expected 20, but got %v", resp)
+		}
+	})
+
+	t.Run("should recover from a failure", func(t *testing.T) {
+		resp, err := async.Run(context.Background(), func(ctx context.Context) (int, error) {
+			return 0, errTest
+		}).
+			Map(context.Background(), func(v int) int { return v + 1 }).
+			Recover(context.Background(), func(err error) int { return -1 }).
+			Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != -1 {
+			t.Fatalf("Expected -1, but got %v", resp)
+		}
+	})
+
+	t.Run("should report a panic", func(t *testing.T) {
+		p := async.Run(context.Background(), func(ctx context.Context) (int, error) {
+			panic("boom")
+		})
+
+		<-p.Done()
+		if !p.Panicked() || !async.Panicked[int](p) {
+			t.Fatal("Expected the promise to be panicked")
+		}
+	})
+
+	t.Run("should work with free functions for cross-type transforms", func(t *testing.T) {
+		p := async.Run(context.Background(), func(ctx context.Context) (int, error) {
+			return 1, nil
+		})
+
+		resp, err := async.Map(context.Background(), async.Future[int](p), strconv.Itoa).Get(context.Background())
+		if err != nil || resp != "1" {
+			t.Fatalf("Expected %q, but got %q, %v", "1", resp, err)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter limits how often work can start using a token bucket.
+// The bucket holds up to burst tokens and is refilled at rate tokens per second.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a RateLimiter allowing rate starts per second with bursts of up to burst starts.
+// The bucket starts full. rate <= 0 means no limit and burst <= 0 is treated as 1.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	b := float64(max(burst, 1))
+	return &RateLimiter{
+		rate:   rate,
+		burst:  b,
+		tokens: b,
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+// It returns the context error in the latter case.
+func (rl *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay := rl.reserve()
+		if delay == 0 {
+			return nil
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token if one is available and returns 0,
+// otherwise it returns how long to wait until the next token.
+func (rl *RateLimiter) reserve() time.Duration {
+	if rl.rate <= 0 {
+		return 0
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+	rl.tokens = min(rl.burst, rl.tokens+now.Sub(rl.last).Seconds()*rl.rate)
+	rl.last = now
+
+	if rl.tokens >= 1 {
+		rl.tokens--
+		return 0
+	}
+
+	return max(time.Duration((1-rl.tokens)/rl.rate*float64(time.Second)), time.Nanosecond)
+}
+
+// GoRateLimited is like Go but waits for a token of rl before running fn.
+// If ctx is done while waiting, the returned Future resolves with the context error and fn is not called.
+//
+// Example:
+//
+//	rl := async.NewRateLimiter(10, 1) // 10 calls per second
+//	fut := async.GoRateLimited(ctx, rl, callAPI)
+func GoRateLimited[T any](ctx context.Context, rl *RateLimiter, fn func(ctx context.Context) (T, error)) Future[T] {
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		if err := rl.Wait(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+
+		return fn(ctx)
+	})
+}
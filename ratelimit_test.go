@@ -0,0 +1,72 @@
+package async_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("should allow a burst then wait for new tokens", func(t *testing.T) {
+		rl := async.NewRateLimiter(100, 2)
+
+		start := time.Now()
+		for i := 0; i < 4; i++ {
+			if err := rl.Wait(context.Background()); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+		}
+
+		if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+			t.Fatalf("Expected to wait for 2 tokens, but took %v", elapsed)
+		}
+	})
+
+	t.Run("should return the context error while waiting", func(t *testing.T) {
+		rl := async.NewRateLimiter(0.001, 1)
+		_ = rl.Wait(context.Background())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if err := rl.Wait(ctx); err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+func TestGoRateLimited(t *testing.T) {
+	t.Run("should return the result of fn", func(t *testing.T) {
+		rl := async.NewRateLimiter(100, 1)
+
+		resp, err := async.GoRateLimited(context.Background(), rl, func(ctx context.Context) (int, error) {
+			return 1, nil
+		}).Get(context.Background())
+		if err != nil || resp != 1 {
+			t.Fatalf("Expected 1, but got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("should not call fn when ctx is done while waiting", func(t *testing.T) {
+		rl := async.NewRateLimiter(0.001, 1)
+		_ = rl.Wait(context.Background())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		called := false
+		_, err := async.GoRateLimited(ctx, rl, func(ctx context.Context) (int, error) {
+			called = true
+			return 1, nil
+		}).Get(context.Background())
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+
+		if called {
+			t.Fatal("Expected fn not to be called")
+		}
+	})
+}
@@ -0,0 +1,166 @@
+package async
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// BackoffStrategy decides how long to wait between attempts.
+type BackoffStrategy interface {
+	// NextDelay returns the delay before the next attempt.
+	// attempt is the number of attempts made so far, starting at 1.
+	NextDelay(attempt int) time.Duration
+}
+
+// ConstantBackoff waits the same duration between attempts.
+type ConstantBackoff time.Duration
+
+// NextDelay returns the constant delay.
+func (b ConstantBackoff) NextDelay(_ int) time.Duration {
+	return time.Duration(b)
+}
+
+// ExponentialBackoff multiplies the delay by Multiplier after each attempt, starting at Base and capped at Max.
+type ExponentialBackoff struct {
+	// Base is the delay after the first attempt.
+	Base time.Duration
+	// Max caps the delay. Zero means no cap.
+	Max time.Duration
+	// Multiplier is the growth factor of the delay. If it's less than 1, 2 is used.
+	Multiplier float64
+	// Jitter randomizes the delay by up to the given fraction, e.g. 0.1 for ±10%. Zero disables jitter.
+	Jitter float64
+}
+
+// NextDelay returns the exponential delay for attempt.
+func (b ExponentialBackoff) NextDelay(attempt int) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	delay := clampDelay(float64(b.Base) * math.Pow(multiplier, float64(max(attempt, 1)-1)))
+	if b.Max > 0 && delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+
+	if b.Jitter > 0 {
+		delay = clampDelay(delay + delay*b.Jitter*(2*rand.Float64()-1))
+	}
+
+	if delay >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+
+	return time.Duration(delay)
+}
+
+// clampDelay caps delay at the largest time.Duration,
+// as converting a larger float, possibly +Inf, to a time.Duration gives a negative one.
+func clampDelay(delay float64) float64 {
+	return min(delay, math.MaxInt64)
+}
+
+// Retry runs fn in a different goroutine and retries it on error, up to attempts times in total.
+// The returned Future resolves with the value of the first successful attempt,
+// or with the error of the last attempt if all attempts fail.
+// If ctx is cancelled between attempts, it stops immediately and resolves with the context error.
+// attempts <= 0 is treated as a single attempt.
+func Retry[T any](ctx context.Context, attempts int, fn func(context.Context) (T, error)) Future[T] {
+	return RetryWithBackoff(ctx, attempts, ConstantBackoff(0), fn)
+}
+
+// RetryWithBackoff is like Retry but waits between attempts according to strategy.
+// The wait is interrupted if ctx is cancelled, and no delay is applied after the final attempt.
+func RetryWithBackoff[T any](ctx context.Context, attempts int, strategy BackoffStrategy, fn func(context.Context) (T, error)) Future[T] {
+	return retry(ctx, attempts, strategy, nil, fn)
+}
+
+// RetryIf is like Retry but only retries when shouldRetry returns true for the error,
+// otherwise it resolves immediately with that error.
+// This avoids wasting attempts on permanent failures.
+//
+// Example:
+//
+//	fut := async.RetryIf(ctx, 3, isTemporary, callAPI)
+func RetryIf[T any](ctx context.Context, attempts int, shouldRetry func(error) bool, fn func(context.Context) (T, error)) Future[T] {
+	return retry(ctx, attempts, ConstantBackoff(0), shouldRetry, fn)
+}
+
+// RetryIfWithBackoff combines RetryIf and RetryWithBackoff:
+// it only retries errors accepted by shouldRetry and waits between attempts according to strategy.
+func RetryIfWithBackoff[T any](ctx context.Context, attempts int, shouldRetry func(error) bool, strategy BackoffStrategy, fn func(context.Context) (T, error)) Future[T] {
+	return retry(ctx, attempts, strategy, shouldRetry, fn)
+}
+
+// retry implements the retry helpers. A nil shouldRetry retries every error.
+func retry[T any](ctx context.Context, attempts int, strategy BackoffStrategy, shouldRetry func(error) bool, fn func(context.Context) (T, error)) Future[T] {
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		var (
+			val T
+			err error
+		)
+
+		for i := 1; ; i++ {
+			val, err = fn(ctx)
+			if err == nil || i >= attempts || (shouldRetry != nil && !shouldRetry(err)) {
+				return val, err
+			}
+
+			if sleepErr := sleep(ctx, strategy.NextDelay(i)); sleepErr != nil {
+				return val, sleepErr
+			}
+		}
+	})
+}
+
+// sleep pauses the current goroutine for at least d, or until ctx is done.
+// It returns the context error if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Poll runs fn in a different goroutine and calls it again every interval until it reports done.
+// The returned Future resolves with the value once fn returns true, with the error if fn fails,
+// or with the context error if ctx is done, which also interrupts the wait between calls.
+//
+// Example:
+//
+//	fut := async.Poll(ctx, time.Second, func(ctx context.Context) (Job, bool, error) {
+//		job, err := getJob(ctx, id)
+//		return job, job.Finished(), err
+//	})
+func Poll[T any](ctx context.Context, interval time.Duration, fn func(context.Context) (T, bool, error)) Future[T] {
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		ticker := time.NewTicker(max(interval, time.Nanosecond))
+		defer ticker.Stop()
+
+		for {
+			val, done, err := fn(ctx)
+			if err != nil || done {
+				return val, err
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				var zero T
+				return zero, ctx.Err()
+			}
+		}
+	})
+}
@@ -0,0 +1,271 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+func TestRetry(t *testing.T) {
+	t.Run("should return the value of the first passing attempt", func(t *testing.T) {
+		calls := 0
+		fut := async.Retry(context.Background(), 3, func(ctx context.Context) (int, error) {
+			calls++
+			if calls < 2 {
+				return 0, errTest
+			}
+
+			return calls, nil
+		})
+
+		resp, err := fut.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != 2 {
+			t.Fatalf("Expected 2, but got %v", resp)
+		}
+	})
+
+	t.Run("should return the last error when all attempts fail", func(t *testing.T) {
+		calls := 0
+		fut := async.Retry(context.Background(), 3, func(ctx context.Context) (int, error) {
+			calls++
+			return 0, errTest
+		})
+
+		_, err := fut.Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		if calls != 3 {
+			t.Fatalf("Expected 3 calls, but got %v", calls)
+		}
+	})
+
+	t.Run("should treat attempts <= 0 as a single attempt", func(t *testing.T) {
+		calls := 0
+		fut := async.Retry(context.Background(), 0, func(ctx context.Context) (int, error) {
+			calls++
+			return 0, errTest
+		})
+
+		_, _ = fut.Get(context.Background())
+		if calls != 1 {
+			t.Fatalf("Expected 1 call, but got %v", calls)
+		}
+	})
+
+	t.Run("should stop when the context is cancelled between attempts", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		calls := 0
+		fut := async.Retry(ctx, 3, func(ctx context.Context) (int, error) {
+			calls++
+			cancel()
+			return 0, errTest
+		})
+
+		_, err := fut.Get(context.Background())
+		if err != context.Canceled {
+			t.Fatalf("Expected %v, but got %v", context.Canceled, err)
+		}
+
+		if calls != 1 {
+			t.Fatalf("Expected 1 call, but got %v", calls)
+		}
+	})
+}
+
+func TestRetryWithBackoff(t *testing.T) {
+	t.Run("should wait between attempts", func(t *testing.T) {
+		calls := 0
+		start := time.Now()
+		fut := async.RetryWithBackoff(context.Background(), 3, async.ConstantBackoff(10*time.Millisecond), func(ctx context.Context) (int, error) {
+			calls++
+			return 0, errTest
+		})
+
+		_, err := fut.Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+			t.Fatalf("Expected two delays, but took %v", elapsed)
+		}
+
+		if calls != 3 {
+			t.Fatalf("Expected 3 calls, but got %v", calls)
+		}
+	})
+
+	t.Run("should interrupt the delay when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		fut := async.RetryWithBackoff(ctx, 3, async.ConstantBackoff(time.Hour), func(ctx context.Context) (int, error) {
+			return 0, errTest
+		})
+
+		_, err := fut.Get(context.Background())
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	t.Run("should not overflow for large attempts without Max", func(t *testing.T) {
+		for _, b := range []async.ExponentialBackoff{
+			{Base: time.Second},
+			{Base: time.Second, Jitter: 0.5},
+		} {
+			for _, attempt := range []int{40, 100, 2000} {
+				if got := b.NextDelay(attempt); got < time.Second {
+					t.Fatalf("Expected a large positive delay for attempt %v, but got %v", attempt, got)
+				}
+			}
+		}
+	})
+
+	t.Run("should grow the delay and cap it at Max", func(t *testing.T) {
+		b := async.ExponentialBackoff{
+			Base:       10 * time.Millisecond,
+			Max:        35 * time.Millisecond,
+			Multiplier: 2,
+		}
+
+		expected := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 35 * time.Millisecond}
+		for i, want := range expected {
+			if got := b.NextDelay(i + 1); got != want {
+				t.Fatalf("Expected %v, but got %v", want, got)
+			}
+		}
+	})
+
+	t.Run("should keep the jitter within bounds", func(t *testing.T) {
+		b := async.ExponentialBackoff{
+			Base:   100 * time.Millisecond,
+			Jitter: 0.1,
+		}
+
+		for i := 0; i < 100; i++ {
+			got := b.NextDelay(1)
+			if got < 90*time.Millisecond || got > 110*time.Millisecond {
+				t.Fatalf("Expected a delay within 10%% of 100ms, but got %v", got)
+			}
+		}
+	})
+}
+
+func TestPoll(t *testing.T) {
+	t.Run("should return the value once done", func(t *testing.T) {
+		calls := 0
+		fut := async.Poll(context.Background(), time.Millisecond, func(ctx context.Context) (int, bool, error) {
+			calls++
+			return calls, calls == 3, nil
+		})
+
+		resp, err := fut.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != 3 {
+			t.Fatalf("Expected 3, but got %v", resp)
+		}
+	})
+
+	t.Run("should return the error of fn", func(t *testing.T) {
+		fut := async.Poll(context.Background(), time.Millisecond, func(ctx context.Context) (int, bool, error) {
+			return 0, false, errTest
+		})
+
+		_, err := fut.Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+
+	t.Run("should interrupt the wait when ctx is done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		fut := async.Poll(ctx, time.Hour, func(ctx context.Context) (int, bool, error) {
+			return 0, false, nil
+		})
+
+		_, err := fut.Get(context.Background())
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+func TestRetryIf(t *testing.T) {
+	errPermanent := errors.New("permanent error")
+	isTemporary := func(err error) bool {
+		return err == errTest
+	}
+
+	t.Run("should retry accepted errors", func(t *testing.T) {
+		calls := 0
+		_, err := async.RetryIf(context.Background(), 3, isTemporary, func(ctx context.Context) (int, error) {
+			calls++
+			return 0, errTest
+		}).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		if calls != 3 {
+			t.Fatalf("Expected 3 calls, but got %v", calls)
+		}
+	})
+
+	t.Run("should stop on rejected errors", func(t *testing.T) {
+		calls := 0
+		_, err := async.RetryIf(context.Background(), 3, isTemporary, func(ctx context.Context) (int, error) {
+			calls++
+			return 0, errPermanent
+		}).Get(context.Background())
+		if err != errPermanent {
+			t.Fatalf("Expected %v, but got %v", errPermanent, err)
+		}
+
+		if calls != 1 {
+			t.Fatalf("Expected 1 call, but got %v", calls)
+		}
+	})
+}
+
+func TestRetryIfWithBackoff(t *testing.T) {
+	t.Run("should wait between accepted retries", func(t *testing.T) {
+		calls := 0
+		start := time.Now()
+		_, err := async.RetryIfWithBackoff(context.Background(), 2, func(err error) bool {
+			return true
+		}, async.ConstantBackoff(10*time.Millisecond), func(ctx context.Context) (int, error) {
+			calls++
+			return 0, errTest
+		}).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+			t.Fatalf("Expected a delay, but took %v", elapsed)
+		}
+
+		if calls != 2 {
+			t.Fatalf("Expected 2 calls, but got %v", calls)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrSchedulerStopped is returned by futures scheduled on a stopped Scheduler.
+var ErrSchedulerStopped = errors.New("async: scheduler stopped")
+
+// Scheduler runs functions at given wall-clock times or periodically.
+// Stopping it halts all the executions that haven't started yet.
+type Scheduler struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+}
+
+// NewScheduler creates a Scheduler running functions with a context derived from ctx.
+// Cancelling ctx has the same effect as calling Stop.
+//
+// Example:
+//
+//	s := async.NewScheduler(ctx)
+//	defer s.Stop()
+//
+//	fut := async.At(s, midnight, rotateLogs)
+//	job := async.Every(s, time.Minute, reportMetrics)
+func NewScheduler(ctx context.Context) *Scheduler {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Scheduler{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Stop halts all future executions of s and cancels the context of running functions.
+// It's safe to call Stop multiple times.
+func (s *Scheduler) Stop() {
+	s.cancel(ErrSchedulerStopped)
+}
+
+// At runs fn at t in a new goroutine and returns a Future of its result.
+// If t is in the past, fn runs immediately. If s is stopped before t,
+// fn is never called and the returned Future fails with ErrSchedulerStopped,
+// or with the cause of the cancellation of the context of s.
+func At[T any](s *Scheduler, t time.Time, fn func(ctx context.Context) (T, error)) Future[T] {
+	fut := newFuture[T]()
+
+	// not built on Go, whose fast path would report context.Canceled rather than the cause
+	go fut.run(s.ctx, func(ctx context.Context) (T, error) {
+		if err := sleep(ctx, time.Until(t)); err != nil {
+			var zero T
+			return zero, context.Cause(ctx)
+		}
+
+		return fn(ctx)
+	})
+
+	return fut
+}
+
+// Job is a function run periodically by Every.
+type Job struct {
+	cancel context.CancelFunc
+	doneCh chan struct{}
+}
+
+// Every runs fn every d in a new goroutine, starting d from now, until the returned Job or s is stopped.
+// Runs never overlap: if fn takes longer than d, the ticks missed meanwhile are dropped.
+// A panic in fn is recovered and doesn't stop the following runs.
+// d <= 0 is treated as the smallest positive duration.
+func Every(s *Scheduler, d time.Duration, fn func(ctx context.Context)) *Job {
+	ctx, cancel := context.WithCancel(s.ctx)
+	job := &Job{
+		cancel: cancel,
+		doneCh: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(job.doneCh)
+
+		ticker := time.NewTicker(max(d, time.Nanosecond))
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if ctx.Err() == nil {
+					_, _ = safeCall(ctx, func(ctx context.Context) (struct{}, error) {
+						fn(ctx)
+						return struct{}{}, nil
+					})
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return job
+}
+
+// Stop halts the future executions of j and cancels the context of a running one.
+// It's safe to call Stop multiple times.
+func (j *Job) Stop() {
+	j.cancel()
+}
+
+// Done returns a channel that's closed once j is stopped and its last run has returned.
+func (j *Job) Done() <-chan struct{} {
+	return j.doneCh
+}
@@ -0,0 +1,130 @@
+package async_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+func TestAt(t *testing.T) {
+	t.Run("should run fn at the given time", func(t *testing.T) {
+		s := async.NewScheduler(context.Background())
+		defer s.Stop()
+
+		start := time.Now()
+		resp, err := async.At(s, start.Add(20*time.Millisecond), func(ctx context.Context) (time.Time, error) {
+			return time.Now(), nil
+		}).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp.Sub(start) < 20*time.Millisecond {
+			t.Fatalf("Expected fn to run after 20ms, but ran after %v", resp.Sub(start))
+		}
+	})
+
+	t.Run("should run immediately when the time is in the past", func(t *testing.T) {
+		s := async.NewScheduler(context.Background())
+		defer s.Stop()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		resp, err := async.At(s, time.Now().Add(-time.Hour), func(ctx context.Context) (int, error) {
+			return 1, nil
+		}).Get(ctx)
+		if err != nil || resp != 1 {
+			t.Fatalf("Expected 1, but got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("should not run fn once stopped", func(t *testing.T) {
+		s := async.NewScheduler(context.Background())
+
+		var called atomic.Bool
+		fut := async.At(s, time.Now().Add(time.Hour), func(ctx context.Context) (int, error) {
+			called.Store(true)
+			return 1, nil
+		})
+		s.Stop()
+
+		if _, err := fut.Get(context.Background()); err != async.ErrSchedulerStopped {
+			t.Fatalf("Expected %v, but got %v", async.ErrSchedulerStopped, err)
+		}
+
+		if _, err := async.At(s, time.Now(), func(ctx context.Context) (int, error) {
+			called.Store(true)
+			return 1, nil
+		}).Get(context.Background()); err != async.ErrSchedulerStopped {
+			t.Fatalf("Expected %v, but got %v", async.ErrSchedulerStopped, err)
+		}
+
+		if called.Load() {
+			t.Fatal("Expected fn not to be called")
+		}
+	})
+}
+
+func TestEvery(t *testing.T) {
+	t.Run("should run fn repeatedly until stopped", func(t *testing.T) {
+		s := async.NewScheduler(context.Background())
+		defer s.Stop()
+
+		var runs atomic.Int32
+		job := async.Every(s, 5*time.Millisecond, func(ctx context.Context) {
+			runs.Add(1)
+		})
+
+		time.Sleep(30 * time.Millisecond)
+		job.Stop()
+		<-job.Done()
+
+		n := runs.Load()
+		if n < 2 {
+			t.Fatalf("Expected several runs, but got %v", n)
+		}
+
+		time.Sleep(15 * time.Millisecond)
+		if runs.Load() != n {
+			t.Fatalf("Expected no run after Stop, but got %v more", runs.Load()-n)
+		}
+	})
+
+	t.Run("should keep running after fn panics", func(t *testing.T) {
+		s := async.NewScheduler(context.Background())
+		defer s.Stop()
+
+		var runs atomic.Int32
+		job := async.Every(s, time.Millisecond, func(ctx context.Context) {
+			if runs.Add(1) == 1 {
+				panic("boom")
+			}
+		})
+		defer job.Stop()
+
+		deadline := time.Now().Add(time.Second)
+		for runs.Load() < 2 {
+			if time.Now().After(deadline) {
+				t.Fatal("Expected fn to run again after panicking")
+			}
+
+			time.Sleep(time.Millisecond)
+		}
+	})
+
+	t.Run("should stop when the scheduler is stopped", func(t *testing.T) {
+		s := async.NewScheduler(context.Background())
+		job := async.Every(s, time.Millisecond, func(ctx context.Context) {})
+
+		s.Stop()
+		select {
+		case <-job.Done():
+		case <-time.After(time.Second):
+			t.Fatal("Expected the job to stop")
+		}
+	})
+}
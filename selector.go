@@ -0,0 +1,123 @@
+package async
+
+import (
+	"context"
+)
+
+// Selector waits for the first of several futures, possibly of different types, to complete.
+type Selector struct {
+	ws       []Waitable
+	handlers []func()
+}
+
+// NewSelector creates an empty Selector.
+//
+// Example:
+//
+//	err := async.NewSelector().
+//		Add(userFut, func() { /* userFut is done */ }).
+//		Add(ordersFut, func() { /* ordersFut is done */ }).
+//		Wait(ctx)
+func NewSelector() *Selector {
+	return &Selector{}
+}
+
+// Add registers w along with the handler to run if w is the first to complete.
+// It returns s so calls can be chained.
+func (s *Selector) Add(w Waitable, handler func()) *Selector {
+	s.ws = append(s.ws, w)
+	s.handlers = append(s.handlers, handler)
+	return s
+}
+
+// Wait blocks until the first registered future completes, then runs its handler on the caller's goroutine.
+// If ctx is done first, it returns the context error without running any handler.
+// If no future is registered, it returns ErrNoFutures.
+func (s *Selector) Wait(ctx context.Context) error {
+	i, err := waitFirst(ctx, s.ws)
+	if err != nil {
+		return err
+	}
+
+	s.handlers[i]()
+	return nil
+}
+
+// waitFirst blocks until the first of ws is done and returns its index.
+// If several are done by the time one is observed, the lowest index is returned.
+func waitFirst(ctx context.Context, ws []Waitable) (int, error) {
+	if len(ws) == 0 {
+		return -1, ErrNoFutures
+	}
+
+	for i, w := range ws {
+		if isDone(w) {
+			return i, nil
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	doneCh := make(chan int, len(ws))
+	for i, w := range ws {
+		go func(i int, w Waitable) {
+			select {
+			case <-w.Done():
+				doneCh <- i
+			case <-ctx.Done():
+			}
+		}(i, w)
+	}
+
+	select {
+	case i := <-doneCh:
+		for j := 0; j < i; j++ {
+			if isDone(ws[j]) {
+				return j, nil
+			}
+		}
+
+		return i, nil
+	case <-ctx.Done():
+		return -1, ctx.Err()
+	}
+}
+
+// AnyOf waits for the first of several futures, possibly of different types, to complete
+// and reports which one it was, so the caller can Get it with its type intact.
+type AnyOf struct {
+	ws []Waitable
+}
+
+// NewAnyOf creates an empty AnyOf.
+//
+// Example:
+//
+//	anyOf := async.NewAnyOf()
+//	userIdx := anyOf.Add(userFut)
+//	anyOf.Add(ordersFut)
+//
+//	i, err := anyOf.Wait(ctx)
+//	if err == nil && i == userIdx {
+//		user, err := userFut.Get(ctx)
+//		// ...
+//	}
+func NewAnyOf() *AnyOf {
+	return &AnyOf{}
+}
+
+// Add registers w and returns its index, starting from 0 in the order of registration.
+func (a *AnyOf) Add(w Waitable) int {
+	a.ws = append(a.ws, w)
+	return len(a.ws) - 1
+}
+
+// Wait blocks until the first registered future completes and returns its index.
+// If several futures are done by the time Wait observes a completion, the lowest index wins,
+// so futures completing at the same time favor the ones registered first.
+// If ctx is done first, it returns -1 and the context error.
+// If no future is registered, it returns -1 and ErrNoFutures.
+func (a *AnyOf) Wait(ctx context.Context) (int, error) {
+	return waitFirst(ctx, a.ws)
+}
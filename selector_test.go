@@ -0,0 +1,130 @@
+package async_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+func TestSelector(t *testing.T) {
+	t.Run("should run the handler of the first completed future", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		slow := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+		fast := async.Go(context.Background(), func(ctx context.Context) (string, error) {
+			time.Sleep(time.Millisecond)
+			return "fast", nil
+		})
+
+		var selected string
+		err := async.NewSelector().
+			Add(slow, func() { selected = "slow" }).
+			Add(fast, func() { selected = "fast" }).
+			Wait(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if selected != "fast" {
+			t.Fatalf("Expected %q, but got %q", "fast", selected)
+		}
+	})
+
+	t.Run("should return the context error without running any handler", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		slow := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		called := false
+		err := async.NewSelector().
+			Add(slow, func() { called = true }).
+			Wait(ctx)
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+
+		if called {
+			t.Fatal("Expected no handler to be called")
+		}
+	})
+}
+
+func TestAnyOf(t *testing.T) {
+	t.Run("should return the index of the first completed future", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		slow := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+		fast := async.Go(context.Background(), func(ctx context.Context) (string, error) {
+			time.Sleep(time.Millisecond)
+			return "fast", nil
+		})
+
+		anyOf := async.NewAnyOf()
+		anyOf.Add(slow)
+		fastIdx := anyOf.Add(fast)
+
+		i, err := anyOf.Wait(context.Background())
+		if err != nil || i != fastIdx {
+			t.Fatalf("Expected %v, but got %v, %v", fastIdx, i, err)
+		}
+
+		resp, err := fast.Get(context.Background())
+		if err != nil || resp != "fast" {
+			t.Fatalf("Expected %q, but got %v, %v", "fast", resp, err)
+		}
+	})
+
+	t.Run("should favor the lowest index when several are done", func(t *testing.T) {
+		anyOf := async.NewAnyOf()
+		anyOf.Add(async.Resolved(1))
+		anyOf.Add(async.Resolved("done"))
+
+		i, err := anyOf.Wait(context.Background())
+		if err != nil || i != 0 {
+			t.Fatalf("Expected 0, but got %v, %v", i, err)
+		}
+	})
+
+	t.Run("should return the context error", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		anyOf := async.NewAnyOf()
+		anyOf.Add(async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		}))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		i, err := anyOf.Wait(ctx)
+		if err != context.DeadlineExceeded || i != -1 {
+			t.Fatalf("Expected -1, %v, but got %v, %v", context.DeadlineExceeded, i, err)
+		}
+	})
+
+	t.Run("should return ErrNoFutures when empty", func(t *testing.T) {
+		_, err := async.NewAnyOf().Wait(context.Background())
+		if err != async.ErrNoFutures {
+			t.Fatalf("Expected %v, but got %v", async.ErrNoFutures, err)
+		}
+	})
+}
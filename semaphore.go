@@ -0,0 +1,178 @@
+package async
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrWeightTooLarge is returned when acquiring more weight than the capacity of a WeightedSemaphore.
+var ErrWeightTooLarge = errors.New("async: weight exceeds semaphore capacity")
+
+// Semaphore limits the number of concurrent holders.
+type Semaphore struct {
+	slots chan struct{}
+}
+
+// NewSemaphore creates a Semaphore with n slots.
+// n <= 0 is treated as a single slot.
+func NewSemaphore(n int) *Semaphore {
+	return &Semaphore{
+		slots: make(chan struct{}, max(n, 1)),
+	}
+}
+
+// Acquire blocks until a slot is available or ctx is done.
+// It returns the context error in the latter case.
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	select {
+	case s.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release frees a slot acquired by Acquire.
+// It panics if it's called more times than Acquire.
+func (s *Semaphore) Release() {
+	select {
+	case <-s.slots:
+	default:
+		panic("async: semaphore released more times than acquired")
+	}
+}
+
+// GoWithSemaphore is like Go but acquires a slot of sem before running fn and releases it once fn returns.
+// If ctx is done while waiting for a slot, the returned Future resolves with the context error and fn is not called.
+//
+// Example:
+//
+//	sem := async.NewSemaphore(10)
+//	fut := async.GoWithSemaphore(ctx, sem, someWorkFn)
+func GoWithSemaphore[T any](ctx context.Context, sem *Semaphore, fn func(ctx context.Context) (T, error)) Future[T] {
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		if err := sem.Acquire(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+		defer sem.Release()
+
+		return fn(ctx)
+	})
+}
+
+// WeightedSemaphore limits the total weight of concurrent holders, for tasks of varying cost.
+// Waiters are served in FIFO order: a large request at the head of the queue
+// isn't starved by smaller ones arriving after it.
+type WeightedSemaphore struct {
+	mu      sync.Mutex
+	size    int64
+	cur     int64
+	waiters list.List
+}
+
+type weightedWaiter struct {
+	weight int64
+	ready  chan struct{}
+}
+
+// NewWeighted creates a WeightedSemaphore with a total capacity of n.
+//
+// Example:
+//
+//	ws := async.NewWeighted(1 << 30) // 1 GiB of memory
+//	fut := async.GoWeighted(ctx, ws, int64(len(payload)), process)
+func NewWeighted(n int64) *WeightedSemaphore {
+	return &WeightedSemaphore{size: n}
+}
+
+// Acquire blocks until weight is available or ctx is done.
+// It returns the context error in the latter case, without acquiring anything.
+// If weight exceeds the capacity of s, it fails immediately with ErrWeightTooLarge.
+func (s *WeightedSemaphore) Acquire(ctx context.Context, weight int64) error {
+	s.mu.Lock()
+	if weight > s.size {
+		s.mu.Unlock()
+		return ErrWeightTooLarge
+	}
+
+	if s.size-s.cur >= weight && s.waiters.Len() == 0 {
+		s.cur += weight
+		s.mu.Unlock()
+		return nil
+	}
+
+	w := &weightedWaiter{weight: weight, ready: make(chan struct{})}
+	elem := s.waiters.PushBack(w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	select {
+	case <-w.ready:
+		// acquired just as ctx was done, give the weight back
+		s.cur -= weight
+	default:
+		s.waiters.Remove(elem)
+	}
+	s.notify()
+	s.mu.Unlock()
+
+	return ctx.Err()
+}
+
+// Release frees weight acquired by Acquire.
+// It panics if more weight is released than held.
+func (s *WeightedSemaphore) Release(weight int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cur -= weight
+	if s.cur < 0 {
+		panic("async: weighted semaphore released more than held")
+	}
+
+	s.notify()
+}
+
+// notify grants the weight of waiters at the head of the queue while there is enough capacity.
+// It must be called with s.mu held.
+func (s *WeightedSemaphore) notify() {
+	for {
+		front := s.waiters.Front()
+		if front == nil {
+			return
+		}
+
+		w := front.Value.(*weightedWaiter)
+		if s.size-s.cur < w.weight {
+			return
+		}
+
+		s.cur += w.weight
+		s.waiters.Remove(front)
+		close(w.ready)
+	}
+}
+
+// GoWeighted is like Go but acquires weight from ws before running fn and releases it once fn returns.
+// If ctx is done while waiting, or weight exceeds the capacity of ws,
+// the returned Future resolves with the error of Acquire and fn is not called.
+func GoWeighted[T any](ctx context.Context, ws *WeightedSemaphore, weight int64, fn func(ctx context.Context) (T, error)) Future[T] {
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		if err := ws.Acquire(ctx, weight); err != nil {
+			var zero T
+			return zero, err
+		}
+		defer ws.Release(weight)
+
+		return fn(ctx)
+	})
+}
@@ -0,0 +1,208 @@
+package async_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+func TestSemaphore(t *testing.T) {
+	t.Run("should return the context error when no slot is available", func(t *testing.T) {
+		sem := async.NewSemaphore(1)
+		if err := sem.Acquire(context.Background()); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if err := sem.Acquire(ctx); err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+
+	t.Run("should panic when released more times than acquired", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("Expected a panic")
+			}
+		}()
+
+		async.NewSemaphore(1).Release()
+	})
+}
+
+func TestGoWithSemaphore(t *testing.T) {
+	t.Run("should not run more than n functions at once", func(t *testing.T) {
+		sem := async.NewSemaphore(2)
+
+		var running, maxRunning int32
+		futs := make([]async.Future[int], 10)
+		for i := range futs {
+			futs[i] = async.GoWithSemaphore(context.Background(), sem, func(ctx context.Context) (int, error) {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					m := atomic.LoadInt32(&maxRunning)
+					if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+						break
+					}
+				}
+
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				return 0, nil
+			})
+		}
+
+		if _, err := async.All(context.Background(), futs).Get(context.Background()); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if maxRunning > 2 {
+			t.Fatalf("Expected at most 2 running functions, but got %v", maxRunning)
+		}
+	})
+
+	t.Run("should not call fn when ctx is done while waiting", func(t *testing.T) {
+		sem := async.NewSemaphore(1)
+		_ = sem.Acquire(context.Background())
+		defer sem.Release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		called := false
+		_, err := async.GoWithSemaphore(ctx, sem, func(ctx context.Context) (int, error) {
+			called = true
+			return 1, nil
+		}).Get(context.Background())
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+
+		if called {
+			t.Fatal("Expected fn not to be called")
+		}
+	})
+}
+
+func TestWeightedSemaphore(t *testing.T) {
+	t.Run("should reject weights larger than the capacity", func(t *testing.T) {
+		ws := async.NewWeighted(2)
+		if err := ws.Acquire(context.Background(), 3); err != async.ErrWeightTooLarge {
+			t.Fatalf("Expected %v, but got %v", async.ErrWeightTooLarge, err)
+		}
+	})
+
+	t.Run("should serve waiters in FIFO order", func(t *testing.T) {
+		ws := async.NewWeighted(2)
+		_ = ws.Acquire(context.Background(), 2)
+
+		largeCh := make(chan struct{})
+		go func() {
+			_ = ws.Acquire(context.Background(), 2)
+			close(largeCh)
+		}()
+		time.Sleep(5 * time.Millisecond)
+
+		smallCh := make(chan struct{})
+		go func() {
+			_ = ws.Acquire(context.Background(), 1)
+			close(smallCh)
+		}()
+		time.Sleep(5 * time.Millisecond)
+
+		ws.Release(1)
+		select {
+		case <-smallCh:
+			t.Fatal("Expected the small request to wait behind the large one")
+		case <-time.After(5 * time.Millisecond):
+		}
+
+		ws.Release(1)
+		<-largeCh
+
+		ws.Release(2)
+		<-smallCh
+		ws.Release(1)
+	})
+
+	t.Run("should return the context error without acquiring", func(t *testing.T) {
+		ws := async.NewWeighted(1)
+		_ = ws.Acquire(context.Background(), 1)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if err := ws.Acquire(ctx, 1); err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+
+		ws.Release(1)
+		if err := ws.Acquire(context.Background(), 1); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+	})
+
+	t.Run("should panic when releasing more than held", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("Expected a panic")
+			}
+		}()
+
+		async.NewWeighted(1).Release(1)
+	})
+}
+
+func TestGoWeighted(t *testing.T) {
+	t.Run("should not exceed the capacity", func(t *testing.T) {
+		ws := async.NewWeighted(4)
+
+		var running, maxRunning int64
+		futs := make([]async.Future[int], 10)
+		for i := range futs {
+			futs[i] = async.GoWeighted(context.Background(), ws, 2, func(ctx context.Context) (int, error) {
+				n := atomic.AddInt64(&running, 2)
+				for {
+					m := atomic.LoadInt64(&maxRunning)
+					if n <= m || atomic.CompareAndSwapInt64(&maxRunning, m, n) {
+						break
+					}
+				}
+
+				time.Sleep(time.Millisecond)
+				atomic.AddInt64(&running, -2)
+				return 1, nil
+			})
+		}
+
+		for _, fut := range futs {
+			if _, err := fut.Get(context.Background()); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+		}
+
+		if maxRunning > 4 {
+			t.Fatalf("Expected a total weight of at most 4, but got %v", maxRunning)
+		}
+	})
+
+	t.Run("should fail without calling fn when the weight is too large", func(t *testing.T) {
+		var called atomic.Bool
+		_, err := async.GoWeighted(context.Background(), async.NewWeighted(1), 2, func(ctx context.Context) (int, error) {
+			called.Store(true)
+			return 1, nil
+		}).Get(context.Background())
+		if err != async.ErrWeightTooLarge {
+			t.Fatalf("Expected %v, but got %v", async.ErrWeightTooLarge, err)
+		}
+
+		if called.Load() {
+			t.Fatal("Expected fn not to be called")
+		}
+	})
+}
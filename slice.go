@@ -0,0 +1,328 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrInvalidBatchSize is returned by Batch when the batch size is not positive.
+var ErrInvalidBatchSize = errors.New("async: batch size must be positive")
+
+// MapSlice runs fn for each element of items concurrently, one goroutine per element,
+// and returns a Future of the transformed slice in input order.
+// If any call fails, the returned Future resolves with the first error in index order.
+// A panic in fn for one element is recovered and fails the aggregate with a *PanicError.
+//
+// Example:
+//
+//	users, err := async.MapSlice(ctx, ids, fetchUser).Get(ctx)
+func MapSlice[T, U any](ctx context.Context, items []T, fn func(context.Context, T) (U, error)) Future[[]U] {
+	return MapSliceN(ctx, 0, items, fn)
+}
+
+// MapSliceN is like MapSlice but never runs more than concurrency calls of fn at once.
+// Once a call fails, in-flight calls are allowed to finish but no new call is started,
+// and the returned Future resolves with the first error in index order.
+// Likewise, no new call is started once ctx is done, and the returned Future resolves with the context error.
+// concurrency <= 0 means unbounded.
+func MapSliceN[T, U any](ctx context.Context, concurrency int, items []T, fn func(context.Context, T) (U, error)) Future[[]U] {
+	return Go(ctx, func(ctx context.Context) ([]U, error) {
+		vals := make([]U, len(items))
+		err := parallel(ctx, concurrency, len(items), func(ctx context.Context, i int) error {
+			var err error
+			vals[i], err = fn(ctx, items[i])
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return vals, nil
+	})
+}
+
+// MapSliceRateLimited is like MapSlice but waits for a token of rl before starting the call for each element,
+// e.g. to call a rate-capped API over a large input. Results are in input order.
+// Once a call fails, no more tokens are acquired and the returned Future resolves with the first error in index order.
+// If ctx is done while waiting for a token, the returned Future resolves with the context error.
+func MapSliceRateLimited[T, U any](ctx context.Context, rl *RateLimiter, items []T, fn func(context.Context, T) (U, error)) Future[[]U] {
+	return Go(ctx, func(ctx context.Context) ([]U, error) {
+		vals := make([]U, len(items))
+		err := parallelPaced(ctx, 0, len(items), rl.Wait, func(ctx context.Context, i int) error {
+			var err error
+			vals[i], err = fn(ctx, items[i])
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return vals, nil
+	})
+}
+
+// Filter evaluates pred for each element of items concurrently
+// and returns a Future of the elements for which pred returned true, in input order.
+// If any call fails, the returned Future resolves with the first error in index order.
+func Filter[T any](ctx context.Context, items []T, pred func(context.Context, T) (bool, error)) Future[[]T] {
+	return FilterN(ctx, 0, items, pred)
+}
+
+// FilterN is like Filter but never runs more than concurrency calls of pred at once,
+// following the same semantics as MapSliceN.
+func FilterN[T any](ctx context.Context, concurrency int, items []T, pred func(context.Context, T) (bool, error)) Future[[]T] {
+	return Go(ctx, func(ctx context.Context) ([]T, error) {
+		keep := make([]bool, len(items))
+		err := parallel(ctx, concurrency, len(items), func(ctx context.Context, i int) error {
+			var err error
+			keep[i], err = pred(ctx, items[i])
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		filtered := make([]T, 0, len(items))
+		for i, item := range items {
+			if keep[i] {
+				filtered = append(filtered, item)
+			}
+		}
+
+		return filtered, nil
+	})
+}
+
+// Batch splits items into batches of size elements, the last one possibly shorter,
+// runs fn for each batch concurrently and returns a Future of the concatenated results in input order.
+// If any batch fails, the returned Future resolves with the first error in index order.
+// size <= 0 is rejected with ErrInvalidBatchSize.
+//
+// Example:
+//
+//	users, err := async.Batch(ctx, 100, ids, fetchUsers).Get(ctx)
+func Batch[T, U any](ctx context.Context, size int, items []T, fn func(context.Context, []T) ([]U, error)) Future[[]U] {
+	if size <= 0 {
+		return Failed[[]U](ErrInvalidBatchSize)
+	}
+
+	return Go(ctx, func(ctx context.Context) ([]U, error) {
+		batches := make([][]U, (len(items)+size-1)/size)
+		err := parallel(ctx, 0, len(batches), func(ctx context.Context, i int) error {
+			start := i * size
+			end := min(start+size, len(items))
+
+			var err error
+			batches[i], err = fn(ctx, items[start:end:end])
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var vals []U
+		for _, batch := range batches {
+			vals = append(vals, batch...)
+		}
+
+		return vals, nil
+	})
+}
+
+// GoMap runs fn for each entry of m concurrently, one goroutine per entry,
+// and returns a Future of a map from the same keys to the results.
+// If any call fails, the returned Future resolves with one of the errors;
+// which one is unspecified when several calls fail, as maps are unordered.
+//
+// Example:
+//
+//	profiles, err := async.GoMap(ctx, usersByID, fetchProfile).Get(ctx)
+func GoMap[K comparable, V, R any](ctx context.Context, m map[K]V, fn func(context.Context, K, V) (R, error)) Future[map[K]R] {
+	return GoMapN(ctx, 0, m, fn)
+}
+
+// GoMapN is like GoMap but never runs more than concurrency calls of fn at once,
+// following the same semantics as MapSliceN. concurrency <= 0 means unbounded.
+func GoMapN[K comparable, V, R any](ctx context.Context, concurrency int, m map[K]V, fn func(context.Context, K, V) (R, error)) Future[map[K]R] {
+	return Go(ctx, func(ctx context.Context) (map[K]R, error) {
+		keys := make([]K, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+
+		// each call writes its own slot, the map is only built once all calls are done
+		vals := make([]R, len(keys))
+		err := parallel(ctx, concurrency, len(keys), func(ctx context.Context, i int) error {
+			var err error
+			vals[i], err = fn(ctx, keys[i], m[keys[i]])
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		res := make(map[K]R, len(keys))
+		for i, k := range keys {
+			res[k] = vals[i]
+		}
+
+		return res, nil
+	})
+}
+
+// ForEachOption configures ForEach and ForEachN.
+type ForEachOption func(*forEachConfig)
+
+type forEachConfig struct {
+	failFast bool
+}
+
+// FailFast controls whether the context passed to the remaining calls is cancelled once a call fails.
+// When enabled, the error of the call triggering the cancellation is the one returned,
+// rather than the context errors of the calls it cancelled. It's disabled by default.
+func FailFast(enabled bool) ForEachOption {
+	return func(cfg *forEachConfig) {
+		cfg.failFast = enabled
+	}
+}
+
+// ForEach runs fn for each element of items concurrently and returns a Future that resolves once all calls are done.
+// If any call fails, the returned Future resolves with the first error in index order.
+func ForEach[T any](ctx context.Context, items []T, fn func(context.Context, T) error, opts ...ForEachOption) Future[struct{}] {
+	return ForEachN(ctx, 0, items, fn, opts...)
+}
+
+// ForEachN is like ForEach but never runs more than concurrency calls of fn at once,
+// following the same semantics as MapSliceN.
+func ForEachN[T any](ctx context.Context, concurrency int, items []T, fn func(context.Context, T) error, opts ...ForEachOption) Future[struct{}] {
+	cfg := &forEachConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return Go(ctx, func(ctx context.Context) (struct{}, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// with failFast, siblings cancelled by the failure usually return the context error,
+		// so the error triggering the cancellation is recorded to win over them
+		var (
+			failOnce sync.Once
+			failErr  error
+		)
+
+		err := parallel(ctx, concurrency, len(items), func(ctx context.Context, i int) error {
+			err := fn(ctx, items[i])
+			if err != nil && cfg.failFast {
+				failOnce.Do(func() {
+					failErr = err
+					cancel()
+				})
+			}
+
+			return err
+		})
+		if failErr != nil {
+			return struct{}{}, failErr
+		}
+
+		return struct{}{}, err
+	})
+}
+
+// parallel calls fn for each index in [0, n) concurrently with at most concurrency calls at once.
+// concurrency <= 0 means unbounded. Once a call fails or ctx is done, no new call is started,
+// and in the latter case the context error is recorded for the index that was about to start.
+// A panic in a call is recovered and treated as a failure with a *PanicError.
+// It waits for started calls to finish and returns the first error in index order.
+func parallel(ctx context.Context, concurrency, n int, fn func(ctx context.Context, i int) error) error {
+	return parallelPaced(ctx, concurrency, n, nil, fn)
+}
+
+// parallelPaced is like parallel but calls pace, if not nil, before starting each call.
+// If pace fails, no new call is started and its error is recorded for the index it was starting.
+func parallelPaced(ctx context.Context, concurrency, n int, pace func(ctx context.Context) error, fn func(ctx context.Context, i int) error) error {
+	var sem chan struct{}
+	if concurrency > 0 {
+		sem = make(chan struct{}, concurrency)
+	}
+
+	var (
+		wg     sync.WaitGroup
+		failed atomic.Bool
+	)
+
+	// pace gets a ctx cancelled on the first failure, so a wait already in progress doesn't outlive it
+	paceCtx, cancelPace := context.WithCancel(ctx)
+	defer cancelPace()
+
+	errs := make([]error, n)
+loop:
+	for i := 0; i < n; i++ {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			break
+		}
+
+		if sem != nil {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				break loop
+			}
+		}
+
+		if failed.Load() {
+			break
+		}
+
+		if pace != nil {
+			if err := pace(paceCtx); err != nil {
+				// the error of the failed call wins over the cancellation it caused
+				if !failed.Load() {
+					errs[i] = err
+				}
+
+				break
+			}
+
+			if failed.Load() {
+				break
+			}
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if sem != nil {
+				defer func() { <-sem }()
+			}
+
+			defer func() {
+				if r := recover(); r != nil {
+					errs[i] = newPanicError(r)
+					failed.Store(true)
+					cancelPace()
+				}
+			}()
+
+			if errs[i] = fn(ctx, i); errs[i] != nil {
+				failed.Store(true)
+				cancelPace()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
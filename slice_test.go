@@ -0,0 +1,547 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+func TestMapSlice(t *testing.T) {
+	t.Run("should transform all items in order", func(t *testing.T) {
+		items := []int{3, 2, 1}
+		fut := async.MapSlice(context.Background(), items, func(ctx context.Context, v int) (string, error) {
+			time.Sleep(time.Duration(v) * time.Millisecond)
+			return strconv.Itoa(v), nil
+		})
+
+		resp, err := fut.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(resp) != 3 || resp[0] != "3" || resp[1] != "2" || resp[2] != "1" {
+			t.Fatalf("Expected [3 2 1], but got %v", resp)
+		}
+	})
+
+	t.Run("should return an error when fn fails", func(t *testing.T) {
+		items := []int{1, 2, 3}
+		fut := async.MapSlice(context.Background(), items, func(ctx context.Context, v int) (string, error) {
+			if v == 2 {
+				return "", errTest
+			}
+
+			return strconv.Itoa(v), nil
+		})
+
+		resp, err := fut.Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		if resp != nil {
+			t.Fatalf("Expected a nil slice, but got %v", resp)
+		}
+	})
+
+	t.Run("should return an empty slice when there is no item", func(t *testing.T) {
+		resp, err := async.MapSlice(context.Background(), []int{}, func(ctx context.Context, v int) (int, error) {
+			return v, nil
+		}).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(resp) != 0 {
+			t.Fatalf("Expected an empty slice, but got %v", resp)
+		}
+	})
+}
+
+func TestMapSliceN(t *testing.T) {
+	t.Run("should not run more than concurrency calls at once", func(t *testing.T) {
+		var running, maxRunning int32
+		items := make([]int, 20)
+		fut := async.MapSliceN(context.Background(), 3, items, func(ctx context.Context, v int) (int, error) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return v, nil
+		})
+
+		resp, err := fut.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(resp) != len(items) {
+			t.Fatalf("Expected %v items, but got %v", len(items), len(resp))
+		}
+
+		if maxRunning > 3 {
+			t.Fatalf("Expected at most 3 running calls, but got %v", maxRunning)
+		}
+	})
+
+	t.Run("should not start new calls after an error", func(t *testing.T) {
+		var calls int32
+		items := []int{1, 2, 3, 4, 5}
+		fut := async.MapSliceN(context.Background(), 1, items, func(ctx context.Context, v int) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			if v == 2 {
+				return 0, errTest
+			}
+
+			return v, nil
+		})
+
+		_, err := fut.Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		if calls != 2 {
+			t.Fatalf("Expected 2 calls, but got %v", calls)
+		}
+	})
+
+	t.Run("should return the first error in index order", func(t *testing.T) {
+		errOther := errors.New("other error")
+		items := []int{1, 2}
+		fut := async.MapSliceN(context.Background(), 0, items, func(ctx context.Context, v int) (int, error) {
+			if v == 1 {
+				time.Sleep(10 * time.Millisecond)
+				return 0, errTest
+			}
+
+			return 0, errOther
+		})
+
+		_, err := fut.Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+
+	t.Run("should stop starting calls once the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var calls atomic.Int32
+		items := make([]int, 100)
+		for i := range items {
+			items[i] = i
+		}
+
+		_, err := async.MapSliceN(ctx, 1, items, func(ctx context.Context, v int) (int, error) {
+			calls.Add(1)
+			if v == 2 {
+				cancel()
+			}
+
+			return v, nil
+		}).Get(context.Background())
+		if err != context.Canceled {
+			t.Fatalf("Expected %v, but got %v", context.Canceled, err)
+		}
+
+		if n := calls.Load(); n >= int32(len(items)) {
+			t.Fatalf("Expected not all elements to run, but got %v calls", n)
+		}
+	})
+}
+
+func TestMapSliceRateLimited(t *testing.T) {
+	t.Run("should space out calls and keep the input order", func(t *testing.T) {
+		rl := async.NewRateLimiter(100, 1)
+
+		start := time.Now()
+		resp, err := async.MapSliceRateLimited(context.Background(), rl, []int{1, 2, 3, 4, 5}, func(_ context.Context, v int) (string, error) {
+			return strconv.Itoa(v), nil
+		}).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+			t.Fatalf("Expected calls to be spaced out, but took %v", elapsed)
+		}
+
+		expected := []string{"1", "2", "3", "4", "5"}
+		for i, v := range resp {
+			if v != expected[i] {
+				t.Fatalf("Expected %v, but got %v", expected, resp)
+			}
+		}
+	})
+
+	t.Run("should stop acquiring tokens after the first error", func(t *testing.T) {
+		rl := async.NewRateLimiter(20, 1)
+
+		var calls atomic.Int32
+		_, err := async.MapSliceRateLimited(context.Background(), rl, []int{1, 2, 3}, func(_ context.Context, v int) (int, error) {
+			calls.Add(1)
+			return 0, errTest
+		}).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		if calls.Load() != 1 {
+			t.Fatalf("Expected a single call, but got %v", calls.Load())
+		}
+	})
+
+	t.Run("should interrupt a pending wait for a token after the first error", func(t *testing.T) {
+		rl := async.NewRateLimiter(2, 1)
+
+		start := time.Now()
+		_, err := async.MapSliceRateLimited(context.Background(), rl, []int{1, 2}, func(_ context.Context, v int) (int, error) {
+			return 0, errTest
+		}).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+			t.Fatalf("Expected the pending wait to be interrupted, but took %v", elapsed)
+		}
+
+		// the interrupted wait must not have taken the next token, which is due 500ms after start
+		if err := rl.Wait(context.Background()); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if elapsed := time.Since(start); elapsed > 800*time.Millisecond {
+			t.Fatalf("Expected the next token to be left, but waited %v", elapsed)
+		}
+	})
+
+	t.Run("should fail with the context error while waiting for a token", func(t *testing.T) {
+		rl := async.NewRateLimiter(1, 1)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := async.MapSliceRateLimited(ctx, rl, []int{1, 2, 3}, func(_ context.Context, v int) (int, error) {
+			return v, nil
+		}).Get(context.Background())
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+func TestFilter(t *testing.T) {
+	t.Run("should keep matching items in order", func(t *testing.T) {
+		items := []int{5, 4, 3, 2, 1}
+		fut := async.Filter(context.Background(), items, func(ctx context.Context, v int) (bool, error) {
+			time.Sleep(time.Duration(v) * time.Millisecond)
+			return v%2 == 1, nil
+		})
+
+		resp, err := fut.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(resp) != 3 || resp[0] != 5 || resp[1] != 3 || resp[2] != 1 {
+			t.Fatalf("Expected [5 3 1], but got %v", resp)
+		}
+	})
+
+	t.Run("should return an error when pred fails", func(t *testing.T) {
+		fut := async.Filter(context.Background(), []int{1, 2}, func(ctx context.Context, v int) (bool, error) {
+			if v == 2 {
+				return false, errTest
+			}
+
+			return true, nil
+		})
+
+		_, err := fut.Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+}
+
+func TestFilterN(t *testing.T) {
+	t.Run("should not run more than concurrency calls at once", func(t *testing.T) {
+		var running, maxRunning int32
+		items := make([]int, 20)
+		fut := async.FilterN(context.Background(), 2, items, func(ctx context.Context, v int) (bool, error) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return true, nil
+		})
+
+		resp, err := fut.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(resp) != len(items) {
+			t.Fatalf("Expected %v items, but got %v", len(items), len(resp))
+		}
+
+		if maxRunning > 2 {
+			t.Fatalf("Expected at most 2 running calls, but got %v", maxRunning)
+		}
+	})
+}
+
+func TestBatch(t *testing.T) {
+	t.Run("should process items in batches and keep the order", func(t *testing.T) {
+		items := []int{1, 2, 3, 4, 5}
+		var batchSizes []int
+		var mu sync.Mutex
+		fut := async.Batch(context.Background(), 2, items, func(ctx context.Context, batch []int) ([]string, error) {
+			mu.Lock()
+			batchSizes = append(batchSizes, len(batch))
+			mu.Unlock()
+
+			vals := make([]string, len(batch))
+			for i, v := range batch {
+				vals[i] = strconv.Itoa(v)
+			}
+
+			return vals, nil
+		})
+
+		resp, err := fut.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(resp) != 5 || resp[0] != "1" || resp[4] != "5" {
+			t.Fatalf("Expected [1 2 3 4 5], but got %v", resp)
+		}
+
+		if len(batchSizes) != 3 {
+			t.Fatalf("Expected 3 batches, but got %v", batchSizes)
+		}
+	})
+
+	t.Run("should return an error when a batch fails", func(t *testing.T) {
+		fut := async.Batch(context.Background(), 2, []int{1, 2, 3}, func(ctx context.Context, batch []int) ([]int, error) {
+			if batch[0] == 3 {
+				return nil, errTest
+			}
+
+			return batch, nil
+		})
+
+		_, err := fut.Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+
+	t.Run("should reject a non-positive size", func(t *testing.T) {
+		fut := async.Batch(context.Background(), 0, []int{1}, func(ctx context.Context, batch []int) ([]int, error) {
+			return batch, nil
+		})
+
+		_, err := fut.Get(context.Background())
+		if err != async.ErrInvalidBatchSize {
+			t.Fatalf("Expected %v, but got %v", async.ErrInvalidBatchSize, err)
+		}
+	})
+}
+
+func TestSlice_Panic(t *testing.T) {
+	var panicErr *async.PanicError
+
+	t.Run("should return a PanicError from MapSlice", func(t *testing.T) {
+		_, err := async.MapSlice(context.Background(), []int{1, 2, 3}, func(ctx context.Context, v int) (int, error) {
+			if v == 2 {
+				panic("boom")
+			}
+
+			return v, nil
+		}).Get(context.Background())
+		if !errors.As(err, &panicErr) {
+			t.Fatalf("Expected a PanicError, but got %v", err)
+		}
+	})
+
+	t.Run("should return a PanicError from Filter", func(t *testing.T) {
+		_, err := async.Filter(context.Background(), []int{1, 2, 3}, func(ctx context.Context, v int) (bool, error) {
+			if v == 2 {
+				panic("boom")
+			}
+
+			return true, nil
+		}).Get(context.Background())
+		if !errors.As(err, &panicErr) {
+			t.Fatalf("Expected a PanicError, but got %v", err)
+		}
+	})
+
+	t.Run("should return a PanicError from Batch", func(t *testing.T) {
+		_, err := async.Batch(context.Background(), 1, []int{1, 2, 3}, func(ctx context.Context, batch []int) ([]int, error) {
+			if batch[0] == 2 {
+				panic("boom")
+			}
+
+			return batch, nil
+		}).Get(context.Background())
+		if !errors.As(err, &panicErr) {
+			t.Fatalf("Expected a PanicError, but got %v", err)
+		}
+	})
+}
+
+func TestForEach(t *testing.T) {
+	t.Run("should run fn for every item", func(t *testing.T) {
+		var sum int32
+		_, err := async.ForEach(context.Background(), []int32{1, 2, 3}, func(ctx context.Context, v int32) error {
+			atomic.AddInt32(&sum, v)
+			return nil
+		}).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if sum != 6 {
+			t.Fatalf("Expected 6, but got %v", sum)
+		}
+	})
+
+	t.Run("should cancel the remaining calls when failing fast", func(t *testing.T) {
+		_, err := async.ForEach(context.Background(), []int{1, 2}, func(ctx context.Context, v int) error {
+			if v == 1 {
+				return errTest
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(time.Second):
+				return errors.New("not cancelled")
+			}
+		}, async.FailFast(true)).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+
+	t.Run("should return the failure over the context errors of cancelled siblings", func(t *testing.T) {
+		_, err := async.ForEach(context.Background(), []int{0, 1}, func(ctx context.Context, v int) error {
+			if v == 1 {
+				return errTest
+			}
+
+			<-ctx.Done()
+			return ctx.Err()
+		}, async.FailFast(true)).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+}
+
+func TestForEachN(t *testing.T) {
+	t.Run("should not start new calls after an error", func(t *testing.T) {
+		var calls int32
+		_, err := async.ForEachN(context.Background(), 1, []int{1, 2, 3}, func(ctx context.Context, v int) error {
+			atomic.AddInt32(&calls, 1)
+			return errTest
+		}).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+
+		if calls != 1 {
+			t.Fatalf("Expected 1 call, but got %v", calls)
+		}
+	})
+}
+
+func TestGoMap(t *testing.T) {
+	t.Run("should map every entry to its result", func(t *testing.T) {
+		m := map[string]int{"a": 1, "b": 2, "c": 3}
+
+		resp, err := async.GoMap(context.Background(), m, func(_ context.Context, k string, v int) (string, error) {
+			return k + strconv.Itoa(v), nil
+		}).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(resp) != 3 || resp["a"] != "a1" || resp["b"] != "b2" || resp["c"] != "c3" {
+			t.Fatalf("Expected map[a:a1 b:b2 c:c3], but got %v", resp)
+		}
+	})
+
+	t.Run("should fail with the error of a call", func(t *testing.T) {
+		m := map[string]int{"a": 1, "b": 2}
+
+		_, err := async.GoMap(context.Background(), m, func(_ context.Context, k string, v int) (int, error) {
+			if k == "b" {
+				return 0, errTest
+			}
+
+			return v, nil
+		}).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+}
+
+func TestGoMapN(t *testing.T) {
+	t.Run("should not run more than concurrency calls at once", func(t *testing.T) {
+		m := make(map[int]int)
+		for i := 0; i < 20; i++ {
+			m[i] = i
+		}
+
+		var running, maxRunning int32
+		resp, err := async.GoMapN(context.Background(), 3, m, func(_ context.Context, k, v int) (int, error) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return v * 2, nil
+		}).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(resp) != len(m) || resp[10] != 20 {
+			t.Fatalf("Expected all entries to be doubled, but got %v", resp)
+		}
+
+		if maxRunning > 3 {
+			t.Fatalf("Expected at most 3 running calls, but got %v", maxRunning)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package async
+
+import (
+	"context"
+)
+
+// SpawnFunc launches task as a sub-task of the current one and returns a Future of its result.
+// task gets its own SpawnFunc to launch sub-tasks one level deeper.
+type SpawnFunc[T any] func(task func(context.Context, SpawnFunc[T]) (T, error)) Future[T]
+
+// Spawn runs fn in a new goroutine like Go for divide-and-conquer algorithms,
+// giving it a SpawnFunc to launch sub-tasks recursively.
+//
+// fn runs at depth 0, and a sub-task spawned from a task at depth d runs at depth d+1.
+// Sub-tasks down to depth maxDepth run in new goroutines, while deeper ones run synchronously
+// in the goroutine calling spawn, which receives an already done Future.
+// This bounds the number of goroutines to the size of the top maxDepth levels of the recursion.
+// maxDepth <= 0 runs all sub-tasks synchronously.
+//
+// Example:
+//
+//	var sum func(ctx context.Context, spawn async.SpawnFunc[int], n *Node) (int, error)
+//	sum = func(ctx context.Context, spawn async.SpawnFunc[int], n *Node) (int, error) {
+//		futs := make([]async.Future[int], len(n.Children))
+//		for i, child := range n.Children {
+//			child := child
+//			futs[i] = spawn(func(ctx context.Context, spawn async.SpawnFunc[int]) (int, error) {
+//				return sum(ctx, spawn, child)
+//			})
+//		}
+//		// wait for futs and add n.Value
+//	}
+func Spawn[T any](ctx context.Context, maxDepth int, fn func(context.Context, SpawnFunc[T]) (T, error)) Future[T] {
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		return fn(ctx, spawnAt[T](ctx, 1, maxDepth))
+	})
+}
+
+// spawnAt returns a SpawnFunc launching sub-tasks at depth.
+func spawnAt[T any](ctx context.Context, depth, maxDepth int) SpawnFunc[T] {
+	return func(task func(context.Context, SpawnFunc[T]) (T, error)) Future[T] {
+		next := spawnAt[T](ctx, depth+1, maxDepth)
+		fn := func(ctx context.Context) (T, error) {
+			return task(ctx, next)
+		}
+
+		if depth > maxDepth {
+			fut := newFuture[T]()
+			fut.run(ctx, fn)
+			return fut
+		}
+
+		return Go(ctx, fn)
+	}
+}
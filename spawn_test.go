@@ -0,0 +1,89 @@
+package async_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bongnv/async"
+)
+
+type treeNode struct {
+	value    int
+	children []*treeNode
+}
+
+func sumTree(ctx context.Context, spawn async.SpawnFunc[int], n *treeNode) (int, error) {
+	futs := make([]async.Future[int], len(n.children))
+	for i, child := range n.children {
+		child := child
+		futs[i] = spawn(func(ctx context.Context, spawn async.SpawnFunc[int]) (int, error) {
+			return sumTree(ctx, spawn, child)
+		})
+	}
+
+	total := n.value
+	for _, fut := range futs {
+		v, err := fut.Get(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		total += v
+	}
+
+	return total, nil
+}
+
+func buildTree(depth, value int) *treeNode {
+	n := &treeNode{value: value}
+	if depth > 0 {
+		n.children = []*treeNode{buildTree(depth-1, value), buildTree(depth-1, value)}
+	}
+
+	return n
+}
+
+func TestSpawn(t *testing.T) {
+	t.Run("should sum a tree recursively", func(t *testing.T) {
+		root := buildTree(5, 1)
+
+		resp, err := async.Spawn(context.Background(), 2, func(ctx context.Context, spawn async.SpawnFunc[int]) (int, error) {
+			return sumTree(ctx, spawn, root)
+		}).Get(context.Background())
+		if err != nil || resp != 63 {
+			t.Fatalf("Expected 63, but got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("should run sub-tasks beyond maxDepth synchronously", func(t *testing.T) {
+		_, err := async.Spawn(context.Background(), 1, func(ctx context.Context, spawn async.SpawnFunc[int]) (int, error) {
+			spawn(func(ctx context.Context, spawn async.SpawnFunc[int]) (int, error) {
+				deep := spawn(func(ctx context.Context, spawn async.SpawnFunc[int]) (int, error) {
+					return 1, nil
+				})
+
+				if !async.IsDone(deep) {
+					t.Errorf("Expected the sub-task beyond maxDepth to be done already")
+				}
+
+				return deep.Get(ctx)
+			}).Get(ctx)
+
+			return 0, nil
+		}).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+	})
+
+	t.Run("should propagate errors from sub-tasks", func(t *testing.T) {
+		_, err := async.Spawn(context.Background(), 0, func(ctx context.Context, spawn async.SpawnFunc[int]) (int, error) {
+			return spawn(func(ctx context.Context, spawn async.SpawnFunc[int]) (int, error) {
+				return 0, errTest
+			}).Get(ctx)
+		}).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+}
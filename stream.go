@@ -0,0 +1,209 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrChannelClosed is returned when a channel is closed before producing a value.
+var ErrChannelClosed = errors.New("async: channel closed")
+
+// AsCompleted returns a channel that emits the Result of each future in futs in completion order, then closes.
+// Exactly one Result is sent per future. If ctx is done, the channel is closed promptly and no more results are sent.
+//
+// Example:
+//
+//	for r := range async.AsCompleted(ctx, futs) {
+//		if r.Err != nil {
+//			// handle the error
+//		}
+//		render(r.Value)
+//	}
+func AsCompleted[T any](ctx context.Context, futs []Future[T]) <-chan Result[T] {
+	outCh := make(chan Result[T])
+
+	go func() {
+		defer close(outCh)
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		resultCh := waitConcurrently(ctx, futs)
+		for range futs {
+			select {
+			case r := <-resultCh:
+				if ctx.Err() != nil {
+					return
+				}
+
+				select {
+				case outCh <- r.Result:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return outCh
+}
+
+// AsOrdered returns a channel that emits the Result of each future in futs strictly in input order, then closes.
+// Results are emitted as soon as all the earlier ones have been emitted,
+// so consumers can start with the earliest results while later ones are still computing.
+// If ctx is done, the channel is closed promptly and no more results are sent.
+func AsOrdered[T any](ctx context.Context, futs []Future[T]) <-chan Result[T] {
+	outCh := make(chan Result[T])
+
+	go func() {
+		defer close(outCh)
+
+		// futures hold their own results, so awaiting them in order is enough to buffer
+		// out-of-order completions without keeping extra copies.
+		for _, fut := range futs {
+			val, err := fut.Get(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+
+			select {
+			case outCh <- Result[T]{Value: val, Err: err}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return outCh
+}
+
+// FromChannel returns a Future that resolves with the first value received from ch.
+// Only the first value is consumed, any later values are left in ch.
+// If ch is closed before producing a value, it resolves with ErrChannelClosed,
+// and if ctx is done first, it resolves with the context error.
+func FromChannel[T any](ctx context.Context, ch <-chan T) Future[T] {
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		select {
+		case val, ok := <-ch:
+			if !ok {
+				return val, ErrChannelClosed
+			}
+
+			return val, nil
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	})
+}
+
+// ToChannel returns a buffered channel that receives a single Result once fut completes, then closes.
+// If ctx is done first, the Result holds the context error. The channel is buffered,
+// so the feeding goroutine never blocks and terminates even if nobody reads from the channel.
+func ToChannel[T any](ctx context.Context, fut Future[T]) <-chan Result[T] {
+	outCh := make(chan Result[T], 1)
+
+	go func() {
+		defer close(outCh)
+
+		val, err := fut.Get(ctx)
+		outCh <- Result[T]{Value: val, Err: err}
+	}()
+
+	return outCh
+}
+
+// Collect returns a Future that drains ch into a slice and resolves once ch is closed.
+// If ctx is done first, it resolves with the results collected so far along with the context error.
+//
+// Example:
+//
+//	results, err := async.Collect(ctx, async.AsCompleted(ctx, futs)).Get(ctx)
+func Collect[T any](ctx context.Context, ch <-chan Result[T]) Future[[]Result[T]] {
+	return Go(ctx, func(ctx context.Context) ([]Result[T], error) {
+		var results []Result[T]
+		for {
+			select {
+			case r, ok := <-ch:
+				if !ok {
+					return results, nil
+				}
+
+				results = append(results, r)
+			case <-ctx.Done():
+				return results, ctx.Err()
+			}
+		}
+	})
+}
+
+// GoStream runs fn in a new goroutine for producers that emit several values over time.
+// fn sends values on the provided channel, which is unbuffered and is closed once fn returns, even if it panics.
+// The returned Future resolves after the channel is closed, with the error returned by fn as its value;
+// its own error is only ever a *PanicError if fn panics.
+//
+// fn should select on ctx.Done when sending, so it can stop once the consumer goes away.
+//
+// Example:
+//
+//	valCh, errFut := async.GoStream(ctx, func(ctx context.Context, ch chan<- Event) error {
+//		return tail(ctx, ch)
+//	})
+//	for ev := range valCh {
+//		// handle ev
+//	}
+//	err, _ := errFut.Get(ctx)
+func GoStream[T any](ctx context.Context, fn func(context.Context, chan<- T) error) (<-chan T, Future[error]) {
+	ch := make(chan T)
+	fut := newFuture[error]()
+
+	go fut.run(ctx, func(ctx context.Context) (error, error) {
+		defer close(ch)
+		return fn(ctx, ch), nil
+	})
+
+	return ch, fut
+}
+
+// MergeStreams fans the Results of chans into a single channel, e.g. to combine several AsCompleted streams.
+// Every Result from every input is forwarded exactly once, and the returned channel is closed once all inputs are closed.
+// If ctx is done, the returned channel is closed promptly and the remaining Results are dropped,
+// without leaving any goroutine behind even if the inputs are never closed.
+func MergeStreams[T any](ctx context.Context, chans ...<-chan Result[T]) <-chan Result[T] {
+	outCh := make(chan Result[T])
+
+	var wg sync.WaitGroup
+	for _, ch := range chans {
+		wg.Add(1)
+		go func(ch <-chan Result[T]) {
+			defer wg.Done()
+
+			for {
+				select {
+				case r, ok := <-ch:
+					if !ok {
+						return
+					}
+
+					select {
+					case outCh <- r:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(ch)
+	}
+
+	go func() {
+		wg.Wait()
+		close(outCh)
+	}()
+
+	return outCh
+}
@@ -0,0 +1,311 @@
+package async_test
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+func TestAsCompleted(t *testing.T) {
+	t.Run("should emit results in completion order", func(t *testing.T) {
+		futs := []async.Future[int]{
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				time.Sleep(20 * time.Millisecond)
+				return 1, nil
+			}),
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				return 2, nil
+			}),
+		}
+
+		var resp []int
+		for r := range async.AsCompleted(context.Background(), futs) {
+			if r.Err != nil {
+				t.Fatalf("Expected no error, but got %v", r.Err)
+			}
+
+			resp = append(resp, r.Value)
+		}
+
+		if len(resp) != 2 || resp[0] != 2 || resp[1] != 1 {
+			t.Fatalf("Expected [2 1], but got %v", resp)
+		}
+	})
+
+	t.Run("should emit exactly one result per future", func(t *testing.T) {
+		futs := make([]async.Future[int], 100)
+		for i := range futs {
+			i := i
+			futs[i] = async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				return i, nil
+			})
+		}
+
+		seen := make(map[int]int)
+		for r := range async.AsCompleted(context.Background(), futs) {
+			seen[r.Value]++
+		}
+
+		if len(seen) != len(futs) {
+			t.Fatalf("Expected %v results, but got %v", len(futs), len(seen))
+		}
+
+		for v, n := range seen {
+			if n != 1 {
+				t.Fatalf("Expected one result for %v, but got %v", v, n)
+			}
+		}
+	})
+
+	t.Run("should close the channel when ctx is cancelled", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		futs := []async.Future[int]{
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				<-testEndCh
+				return 1, nil
+			}),
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		for r := range async.AsCompleted(ctx, futs) {
+			t.Fatalf("Expected no result, but got %v", r)
+		}
+	})
+}
+
+func TestFromChannel(t *testing.T) {
+	t.Run("should return the first value only", func(t *testing.T) {
+		ch := make(chan int, 2)
+		ch <- 1
+		ch <- 2
+
+		resp, err := async.FromChannel(context.Background(), ch).Get(context.Background())
+		if err != nil || resp != 1 {
+			t.Fatalf("Expected 1, but got %v, %v", resp, err)
+		}
+
+		if len(ch) != 1 {
+			t.Fatalf("Expected one value left, but got %v", len(ch))
+		}
+	})
+
+	t.Run("should return ErrChannelClosed when the channel is closed", func(t *testing.T) {
+		ch := make(chan int)
+		close(ch)
+
+		_, err := async.FromChannel(context.Background(), ch).Get(context.Background())
+		if err != async.ErrChannelClosed {
+			t.Fatalf("Expected %v, but got %v", async.ErrChannelClosed, err)
+		}
+	})
+
+	t.Run("should return the context error when ctx is done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := async.FromChannel(ctx, make(chan int)).Get(context.Background())
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+func TestToChannel(t *testing.T) {
+	t.Run("should send a single result then close", func(t *testing.T) {
+		ch := async.ToChannel(context.Background(), async.Resolved(1))
+
+		r := <-ch
+		if r.Err != nil || r.Value != 1 {
+			t.Fatalf("Expected {1 <nil>}, but got %v", r)
+		}
+
+		if _, ok := <-ch; ok {
+			t.Fatal("Expected the channel to be closed")
+		}
+	})
+
+	t.Run("should send the context error when ctx is done", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		r := <-async.ToChannel(ctx, fut)
+		if r.Err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, r.Err)
+		}
+	})
+}
+
+func TestCollect(t *testing.T) {
+	t.Run("should collect all results until the channel is closed", func(t *testing.T) {
+		futs := []async.Future[int]{async.Resolved(1), async.Failed[int](errTest)}
+
+		resp, err := async.Collect(context.Background(), async.AsCompleted(context.Background(), futs)).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(resp) != 2 {
+			t.Fatalf("Expected 2 results, but got %v", resp)
+		}
+	})
+
+	t.Run("should return the partial results with the context error", func(t *testing.T) {
+		ch := make(chan async.Result[int], 1)
+		ch <- async.Result[int]{Value: 1}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		resp, err := async.Collect(ctx, ch).Get(context.Background())
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+
+		if len(resp) != 1 || resp[0].Value != 1 {
+			t.Fatalf("Expected the collected result, but got %v", resp)
+		}
+	})
+}
+
+func TestAsOrdered(t *testing.T) {
+	t.Run("should emit results in input order", func(t *testing.T) {
+		futs := []async.Future[int]{
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				time.Sleep(20 * time.Millisecond)
+				return 1, nil
+			}),
+			async.Resolved(2),
+			async.Failed[int](errTest),
+		}
+
+		var resp []async.Result[int]
+		for r := range async.AsOrdered(context.Background(), futs) {
+			resp = append(resp, r)
+		}
+
+		if len(resp) != 3 || resp[0].Value != 1 || resp[1].Value != 2 || resp[2].Err != errTest {
+			t.Fatalf("Expected results in input order, but got %v", resp)
+		}
+	})
+
+	t.Run("should close the channel when ctx is cancelled", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		futs := []async.Future[int]{
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				<-testEndCh
+				return 1, nil
+			}),
+			async.Resolved(2),
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		for r := range async.AsOrdered(ctx, futs) {
+			t.Fatalf("Expected no result, but got %v", r)
+		}
+	})
+}
+
+func TestGoStream(t *testing.T) {
+	t.Run("should stream all values then resolve with the error of fn", func(t *testing.T) {
+		valCh, errFut := async.GoStream(context.Background(), func(ctx context.Context, ch chan<- int) error {
+			for i := 0; i < 3; i++ {
+				ch <- i
+			}
+
+			return errTest
+		})
+
+		var values []int
+		for v := range valCh {
+			values = append(values, v)
+		}
+
+		if !reflect.DeepEqual(values, []int{0, 1, 2}) {
+			t.Fatalf("Expected %v, but got %v", []int{0, 1, 2}, values)
+		}
+
+		fnErr, err := errFut.Get(context.Background())
+		if err != nil || fnErr != errTest {
+			t.Fatalf("Expected %v, but got %v, %v", errTest, fnErr, err)
+		}
+	})
+
+	t.Run("should let fn observe cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		valCh, errFut := async.GoStream(ctx, func(ctx context.Context, ch chan<- int) error {
+			for i := 0; ; i++ {
+				select {
+				case ch <- i:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		})
+
+		<-valCh
+		cancel()
+
+		fnErr, err := errFut.Get(context.Background())
+		if err != nil || fnErr != context.Canceled {
+			t.Fatalf("Expected %v, but got %v, %v", context.Canceled, fnErr, err)
+		}
+
+		if _, ok := <-valCh; ok {
+			t.Fatal("Expected the channel to be closed")
+		}
+	})
+}
+
+func TestMergeStreams(t *testing.T) {
+	t.Run("should forward every result exactly once", func(t *testing.T) {
+		first := async.AsCompleted(context.Background(), []async.Future[int]{async.Resolved(1), async.Resolved(2)})
+		second := async.AsCompleted(context.Background(), []async.Future[int]{async.Resolved(3)})
+
+		var values []int
+		for r := range async.MergeStreams(context.Background(), first, second) {
+			values = append(values, r.Value)
+		}
+
+		sort.Ints(values)
+		if !reflect.DeepEqual(values, []int{1, 2, 3}) {
+			t.Fatalf("Expected %v, but got %v", []int{1, 2, 3}, values)
+		}
+	})
+
+	t.Run("should close the channel when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		pending := make(chan async.Result[int])
+
+		outCh := async.MergeStreams(ctx, pending)
+		cancel()
+
+		select {
+		case _, ok := <-outCh:
+			if ok {
+				t.Fatal("Expected the channel to be closed")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected the channel to be closed promptly")
+		}
+	})
+}
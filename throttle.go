@@ -0,0 +1,93 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ThrottlerOption configures a Throttler.
+type ThrottlerOption func(*throttlerConfig)
+
+type throttlerConfig struct {
+	trailing bool
+}
+
+// Trailing controls whether a Throttler runs a trailing execution at the end of the cooldown
+// when it has been triggered during the cooldown. It's disabled by default.
+func Trailing(enabled bool) ThrottlerOption {
+	return func(cfg *throttlerConfig) {
+		cfg.trailing = enabled
+	}
+}
+
+// Throttler ensures a function starts at most once per interval.
+type Throttler[T any] struct {
+	interval time.Duration
+	fn       func(context.Context) (T, error)
+	trailing bool
+
+	mu        sync.Mutex
+	last      *futureImpl[T]
+	lastStart time.Time
+	next      *futureImpl[T]
+}
+
+// NewThrottler creates a Throttler that runs fn at most once per interval.
+// Executions happen on the leading edge: the first trigger after the cooldown starts fn immediately.
+func NewThrottler[T any](interval time.Duration, fn func(context.Context) (T, error), opts ...ThrottlerOption) *Throttler[T] {
+	cfg := &throttlerConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Throttler[T]{
+		interval: interval,
+		fn:       fn,
+		trailing: cfg.trailing,
+	}
+}
+
+// Trigger starts fn with ctx if the cooldown has passed and returns a Future of its result.
+// Within the cooldown, it returns the Future of the in-flight or most recent execution,
+// unless trailing executions are enabled, in which case it returns the Future of the execution
+// scheduled at the end of the cooldown. A trailing execution runs with the context of the trigger that scheduled it.
+func (t *Throttler[T]) Trigger(ctx context.Context) Future[T] {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.next != nil {
+		return t.next
+	}
+
+	now := time.Now()
+	if t.last == nil || now.Sub(t.lastStart) >= t.interval {
+		t.last = newFuture[T]()
+		t.lastStart = now
+		go t.last.run(ctx, t.fn)
+		return t.last
+	}
+
+	if !t.trailing {
+		return t.last
+	}
+
+	t.next = newFuture[T]()
+	time.AfterFunc(t.lastStart.Add(t.interval).Sub(now), func() {
+		t.fireTrailing(ctx)
+	})
+
+	return t.next
+}
+
+// fireTrailing starts the scheduled trailing execution.
+func (t *Throttler[T]) fireTrailing(ctx context.Context) {
+	t.mu.Lock()
+	fut := t.next
+	t.next = nil
+	t.last = fut
+	t.lastStart = time.Now()
+	t.mu.Unlock()
+
+	fut.run(ctx, t.fn)
+}
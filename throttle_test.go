@@ -0,0 +1,64 @@
+package async_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+func TestThrottler(t *testing.T) {
+	t.Run("should run on the leading edge and share the result within the cooldown", func(t *testing.T) {
+		var calls int32
+		th := async.NewThrottler(time.Hour, func(ctx context.Context) (int32, error) {
+			return atomic.AddInt32(&calls, 1), nil
+		})
+
+		for i := 0; i < 3; i++ {
+			resp, err := th.Trigger(context.Background()).Get(context.Background())
+			if err != nil || resp != 1 {
+				t.Fatalf("Expected 1, but got %v, %v", resp, err)
+			}
+		}
+	})
+
+	t.Run("should run again after the cooldown", func(t *testing.T) {
+		var calls int32
+		th := async.NewThrottler(10*time.Millisecond, func(ctx context.Context) (int32, error) {
+			return atomic.AddInt32(&calls, 1), nil
+		})
+
+		_, _ = th.Trigger(context.Background()).Get(context.Background())
+		time.Sleep(20 * time.Millisecond)
+
+		resp, err := th.Trigger(context.Background()).Get(context.Background())
+		if err != nil || resp != 2 {
+			t.Fatalf("Expected 2, but got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("should run a trailing execution when enabled", func(t *testing.T) {
+		var calls int32
+		th := async.NewThrottler(10*time.Millisecond, func(ctx context.Context) (int32, error) {
+			return atomic.AddInt32(&calls, 1), nil
+		}, async.Trailing(true))
+
+		first := th.Trigger(context.Background())
+		second := th.Trigger(context.Background())
+		third := th.Trigger(context.Background())
+
+		resp, err := first.Get(context.Background())
+		if err != nil || resp != 1 {
+			t.Fatalf("Expected 1, but got %v, %v", resp, err)
+		}
+
+		for _, fut := range []async.Future[int32]{second, third} {
+			resp, err := fut.Get(context.Background())
+			if err != nil || resp != 2 {
+				t.Fatalf("Expected 2, but got %v, %v", resp, err)
+			}
+		}
+	})
+}
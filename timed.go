@@ -0,0 +1,53 @@
+package async
+
+import (
+	"context"
+	"time"
+)
+
+// TimedFuture is a Future that also reports how long its function took, e.g. for latency tracking.
+type TimedFuture[T any] struct {
+	*futureImpl[T]
+	start time.Time
+	end   time.Time
+}
+
+// GoTimed is like Go but returns a TimedFuture recording when fn was launched and when it returned.
+//
+// Example:
+//
+//	fut := async.GoTimed(ctx, fetchUser)
+//	user, err := fut.Get(ctx)
+//	log.Printf("fetchUser took %v", fut.Elapsed())
+func GoTimed[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) *TimedFuture[T] {
+	fut := &TimedFuture[T]{
+		futureImpl: newFuture[T](),
+		start:      time.Now(),
+	}
+
+	if err := ctx.Err(); err != nil {
+		var zero T
+		fut.end = fut.start
+		fut.resolve(zero, err)
+		return fut
+	}
+
+	go fut.run(ctx, func(ctx context.Context) (T, error) {
+		defer func() {
+			fut.end = time.Now()
+		}()
+
+		return fn(ctx)
+	})
+
+	return fut
+}
+
+// Elapsed returns how long fn took once f is done, or the time elapsed since it was launched otherwise.
+func (f *TimedFuture[T]) Elapsed() time.Duration {
+	if isDone(f.futureImpl) {
+		return f.end.Sub(f.start)
+	}
+
+	return time.Since(f.start)
+}
@@ -0,0 +1,50 @@
+package async_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+func TestGoTimed(t *testing.T) {
+	t.Run("should report the duration of fn", func(t *testing.T) {
+		fut := async.GoTimed(context.Background(), func(ctx context.Context) (int, error) {
+			time.Sleep(20 * time.Millisecond)
+			return 1, nil
+		})
+
+		var _ async.Future[int] = fut
+
+		resp, err := fut.Get(context.Background())
+		if err != nil || resp != 1 {
+			t.Fatalf("Expected 1, but got %v, %v", resp, err)
+		}
+
+		elapsed := fut.Elapsed()
+		if elapsed < 20*time.Millisecond {
+			t.Fatalf("Expected at least 20ms, but got %v", elapsed)
+		}
+
+		time.Sleep(5 * time.Millisecond)
+		if fut.Elapsed() != elapsed {
+			t.Fatalf("Expected the elapsed time to be fixed once done, but got %v then %v", elapsed, fut.Elapsed())
+		}
+	})
+
+	t.Run("should report the time elapsed so far before completion", func(t *testing.T) {
+		releaseCh := make(chan struct{})
+		defer close(releaseCh)
+
+		fut := async.GoTimed(context.Background(), func(ctx context.Context) (int, error) {
+			<-releaseCh
+			return 1, nil
+		})
+
+		time.Sleep(10 * time.Millisecond)
+		if elapsed := fut.Elapsed(); elapsed < 10*time.Millisecond {
+			t.Fatalf("Expected at least 10ms, but got %v", elapsed)
+		}
+	})
+}
@@ -0,0 +1,164 @@
+package async
+
+import (
+	"context"
+	"time"
+)
+
+// WithTimeout returns a Future that resolves with the result of fut if it completes within d,
+// otherwise it resolves with context.DeadlineExceeded.
+// If fut completes just as the timer fires, the result of fut wins.
+// The underlying work of fut is not cancelled.
+func WithTimeout[T any](fut Future[T], d time.Duration) Future[T] {
+	timeoutFut := newFuture[T]()
+
+	go func() {
+		if !awaitWithin(fut, d) {
+			var zero T
+			timeoutFut.resolve(zero, context.DeadlineExceeded)
+			return
+		}
+
+		timeoutFut.resolve(fut.Get(context.Background()))
+	}()
+
+	return timeoutFut
+}
+
+// OrElseAfter returns a Future that resolves with the result of fut if it completes within d,
+// otherwise it resolves successfully with fallback, e.g. for best-effort enrichment with a latency budget.
+// Like WithTimeout, the result of fut wins if it completes just as the timer fires,
+// and the underlying work of fut is left running.
+func OrElseAfter[T any](fut Future[T], d time.Duration, fallback T) Future[T] {
+	fallbackFut := newFuture[T]()
+
+	go func() {
+		if !awaitWithin(fut, d) {
+			fallbackFut.resolve(fallback, nil)
+			return
+		}
+
+		fallbackFut.resolve(fut.Get(context.Background()))
+	}()
+
+	return fallbackFut
+}
+
+// awaitWithin waits up to d for w and reports whether it's done.
+// If w completes just as the timer fires, it's considered done in time.
+func awaitWithin(w Waitable, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-w.Done():
+		return true
+	case <-timer.C:
+		return isDone(w)
+	}
+}
+
+// Delay is like Go but waits for d before running fn.
+// If ctx is done during the wait, the returned Future resolves with the context error and fn is never called.
+//
+// Example:
+//
+//	fut := async.Delay(ctx, time.Minute, refreshCache)
+func Delay[T any](ctx context.Context, d time.Duration, fn func(context.Context) (T, error)) Future[T] {
+	return Go(ctx, func(ctx context.Context) (T, error) {
+		if err := sleep(ctx, d); err != nil {
+			var zero T
+			return zero, err
+		}
+
+		return fn(ctx)
+	})
+}
+
+// GoWithDeadline is like Go but runs fn with a context derived from ctx with the given deadline,
+// so a cooperative fn stops early. Get returns context.DeadlineExceeded once the deadline passes,
+// even if fn hasn't returned yet, keeping the effective timeout of the caller and the worker consistent.
+func GoWithDeadline[T any](ctx context.Context, deadline time.Time, fn func(context.Context) (T, error)) Future[T] {
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	fut := newFuture[T]()
+	inner := Go(ctx, fn)
+
+	go func() {
+		defer cancel()
+		fut.resolve(inner.Get(ctx))
+	}()
+
+	return fut
+}
+
+// GetWithTimeout waits up to d for the result of fut without requiring a context.
+// It returns context.DeadlineExceeded if fut isn't done within d, and the internal timer is released
+// as soon as the call returns. The underlying work of fut is not cancelled.
+func GetWithTimeout[T any](fut Future[T], d time.Duration) (T, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	return fut.Get(ctx)
+}
+
+// GoAll runs each of fns in a new goroutine with a single deadline budget from now, shared by all of them,
+// and returns a Future of their values in the order of fns.
+// If any call fails, the returned Future resolves with the first error in index order.
+// Once the budget elapses, every call sees its context cancelled and the returned Future
+// resolves with context.DeadlineExceeded.
+//
+// Example:
+//
+//	pages, err := async.GoAll(ctx, 200*time.Millisecond, fetchHeader, fetchBody, fetchFooter).Get(ctx)
+func GoAll[T any](ctx context.Context, budget time.Duration, fns ...func(context.Context) (T, error)) Future[[]T] {
+	return Go(ctx, func(ctx context.Context) ([]T, error) {
+		ctx, cancel := context.WithTimeout(ctx, budget)
+		defer cancel()
+
+		futs := make([]Future[T], len(fns))
+		for i, fn := range fns {
+			futs[i] = Go(ctx, fn)
+		}
+
+		vals, err := All(ctx, futs).Get(ctx)
+		if ctxErr := ctx.Err(); err != nil && ctxErr == context.DeadlineExceeded {
+			return nil, ctxErr
+		}
+
+		return vals, err
+	})
+}
+
+// GetAllWithin waits up to d for futs and returns the Result of each of them in the order of futs,
+// for best-effort batch reads. Results of futures not done within d are marked Incomplete.
+// The returned error is only non-nil if ctx is done before d elapses, in which case it's the context error
+// and the results hold what was done by then.
+//
+// Example:
+//
+//	results, err := async.GetAllWithin(ctx, 100*time.Millisecond, futs)
+func GetAllWithin[T any](ctx context.Context, d time.Duration, futs []Future[T]) ([]Result[T], error) {
+	waitCtx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+
+loop:
+	for _, fut := range futs {
+		select {
+		case <-fut.Done():
+		case <-waitCtx.Done():
+			break loop
+		}
+	}
+
+	results := make([]Result[T], len(futs))
+	for i, fut := range futs {
+		if !isDone(fut) {
+			results[i].Incomplete = true
+			continue
+		}
+
+		results[i].Value, results[i].Err = fut.Get(context.Background())
+	}
+
+	return results, ctx.Err()
+}
@@ -0,0 +1,293 @@
+package async_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+func TestWithTimeout(t *testing.T) {
+	t.Run("should return the result when fut completes in time", func(t *testing.T) {
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			return 1, nil
+		})
+
+		resp, err := async.WithTimeout(fut, time.Second).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp != 1 {
+			t.Fatalf("Expected 1, but got %v", resp)
+		}
+	})
+
+	t.Run("should return an error when the duration passes", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+
+		_, err := async.WithTimeout(fut, 10*time.Millisecond).Get(context.Background())
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+
+	t.Run("should prefer the result when fut is already done", func(t *testing.T) {
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			return 1, nil
+		})
+		<-fut.Done()
+
+		for i := 0; i < 100; i++ {
+			resp, err := async.WithTimeout(fut, 0).Get(context.Background())
+			if err != nil || resp != 1 {
+				t.Fatalf("Expected 1, but got %v, %v", resp, err)
+			}
+		}
+	})
+}
+
+func TestDelay(t *testing.T) {
+	t.Run("should run fn after the delay", func(t *testing.T) {
+		start := time.Now()
+		resp, err := async.Delay(context.Background(), 10*time.Millisecond, func(ctx context.Context) (time.Duration, error) {
+			return time.Since(start), nil
+		}).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp < 10*time.Millisecond {
+			t.Fatalf("Expected fn to run after 10ms, but it ran after %v", resp)
+		}
+	})
+
+	t.Run("should not call fn when ctx is done during the delay", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		called := false
+		_, err := async.Delay(ctx, time.Hour, func(ctx context.Context) (int, error) {
+			called = true
+			return 1, nil
+		}).Get(context.Background())
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+
+		if called {
+			t.Fatal("Expected fn not to be called")
+		}
+	})
+}
+
+func TestGoWithDeadline(t *testing.T) {
+	t.Run("should pass the deadline to fn", func(t *testing.T) {
+		deadline := time.Now().Add(time.Hour)
+		resp, err := async.GoWithDeadline(context.Background(), deadline, func(ctx context.Context) (time.Time, error) {
+			d, _ := ctx.Deadline()
+			return d, nil
+		}).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if !resp.Equal(deadline) {
+			t.Fatalf("Expected %v, but got %v", deadline, resp)
+		}
+	})
+
+	t.Run("should return DeadlineExceeded once the deadline passes", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		fut := async.GoWithDeadline(context.Background(), time.Now().Add(10*time.Millisecond), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+
+		_, err := fut.Get(context.Background())
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+func TestGetWithTimeout(t *testing.T) {
+	t.Run("should return the value when the future completes in time", func(t *testing.T) {
+		resp, err := async.GetWithTimeout(async.Resolved(1), 10*time.Millisecond)
+		if err != nil || resp != 1 {
+			t.Fatalf("Expected 1, but got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("should return context.DeadlineExceeded when the timeout elapses", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+
+		_, err := async.GetWithTimeout(fut, 10*time.Millisecond)
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+func TestOrElseAfter(t *testing.T) {
+	t.Run("should return the value when it arrives in time", func(t *testing.T) {
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			return 1, nil
+		})
+
+		resp, err := async.OrElseAfter(fut, time.Second, -1).Get(context.Background())
+		if err != nil || resp != 1 {
+			t.Fatalf("Expected 1, but got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("should return the fallback without error when the value is late", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		fut := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+
+		resp, err := async.OrElseAfter(fut, 10*time.Millisecond, -1).Get(context.Background())
+		if err != nil || resp != -1 {
+			t.Fatalf("Expected -1, but got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("should favor the value when it's ready as the timer fires", func(t *testing.T) {
+		resp, err := async.OrElseAfter(async.Resolved(1), 0, -1).Get(context.Background())
+		if err != nil || resp != 1 {
+			t.Fatalf("Expected 1, but got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("should forward the error of the future", func(t *testing.T) {
+		_, err := async.OrElseAfter(async.Failed[int](errTest), time.Second, -1).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+}
+
+func TestGoAll(t *testing.T) {
+	t.Run("should return the values in order", func(t *testing.T) {
+		resp, err := async.GoAll(context.Background(), time.Second,
+			func(ctx context.Context) (int, error) {
+				time.Sleep(5 * time.Millisecond)
+				return 1, nil
+			},
+			func(ctx context.Context) (int, error) {
+				return 2, nil
+			},
+		).Get(context.Background())
+
+		if err != nil || len(resp) != 2 || resp[0] != 1 || resp[1] != 2 {
+			t.Fatalf("Expected [1 2], but got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("should cancel all calls once the budget elapses", func(t *testing.T) {
+		var cancelled atomic.Int32
+		wait := func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			cancelled.Add(1)
+			return 0, errTest
+		}
+
+		_, err := async.GoAll(context.Background(), 10*time.Millisecond, wait, wait).Get(context.Background())
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		if cancelled.Load() != 2 {
+			t.Fatalf("Expected all calls to be cancelled, but got %v", cancelled.Load())
+		}
+	})
+
+	t.Run("should return the first error", func(t *testing.T) {
+		_, err := async.GoAll(context.Background(), time.Second,
+			func(ctx context.Context) (int, error) {
+				return 0, errTest
+			},
+		).Get(context.Background())
+
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+}
+
+func TestGetAllWithin(t *testing.T) {
+	t.Run("should mark futures not done in time as incomplete", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		futs := []async.Future[int]{
+			async.Resolved(1),
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				<-testEndCh
+				return 2, nil
+			}),
+			async.Failed[int](errTest),
+		}
+
+		results, err := async.GetAllWithin(context.Background(), 10*time.Millisecond, futs)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		expected := []async.Result[int]{
+			{Value: 1},
+			{Incomplete: true},
+			{Err: errTest},
+		}
+		for i, r := range results {
+			if r != expected[i] {
+				t.Fatalf("Expected %v, but got %v", expected, results)
+			}
+		}
+	})
+
+	t.Run("should return the context error when the context is done first", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		futs := []async.Future[int]{
+			async.Go(context.Background(), func(ctx context.Context) (int, error) {
+				<-testEndCh
+				return 1, nil
+			}),
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		results, err := async.GetAllWithin(ctx, time.Second, futs)
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+
+		if !results[0].Incomplete {
+			t.Fatalf("Expected an incomplete result, but got %v", results[0])
+		}
+	})
+}
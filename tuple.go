@@ -0,0 +1,116 @@
+package async
+
+import (
+	"context"
+)
+
+// Pair holds the results of two futures.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Tuple2 holds the results of two futures. It has the same fields as Pair.
+type Tuple2[A, B any] Pair[A, B]
+
+// Tuple3 holds the results of three futures.
+type Tuple3[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// Zip returns a Future that resolves once both a and b succeed.
+// If either fails, it resolves with the error of whichever fails first.
+// ctx governs the combined wait independently of the contexts that produced a and b.
+func Zip[A, B any](ctx context.Context, a Future[A], b Future[B]) Future[Pair[A, B]] {
+	return Go(ctx, func(ctx context.Context) (Pair[A, B], error) {
+		var p Pair[A, B]
+		err := awaitEach(ctx, []Waitable{a, b}, func(i int) (err error) {
+			switch i {
+			case 0:
+				p.First, err = a.Get(ctx)
+			case 1:
+				p.Second, err = b.Get(ctx)
+			}
+
+			return err
+		})
+		if err != nil {
+			return Pair[A, B]{}, err
+		}
+
+		return p, nil
+	})
+}
+
+// Combine2 returns a Future that resolves once both a and b succeed.
+// If either fails, it resolves with the error of whichever fails first.
+func Combine2[A, B any](ctx context.Context, a Future[A], b Future[B]) Future[Tuple2[A, B]] {
+	return Map(ctx, Zip(ctx, a, b), func(p Pair[A, B]) Tuple2[A, B] {
+		return Tuple2[A, B](p)
+	})
+}
+
+// Combine3 returns a Future that resolves once a, b and c all succeed.
+// The futures are awaited concurrently, so if any fails, it resolves with the error of whichever fails first,
+// even if another future is still running.
+//
+// Example:
+//
+//	fut := async.Combine3(ctx, userFut, ordersFut, prefsFut)
+//	t, err := fut.Get(ctx)
+//	// t.First, t.Second and t.Third hold the results
+func Combine3[A, B, C any](ctx context.Context, a Future[A], b Future[B], c Future[C]) Future[Tuple3[A, B, C]] {
+	return Go(ctx, func(ctx context.Context) (Tuple3[A, B, C], error) {
+		var t Tuple3[A, B, C]
+		err := awaitEach(ctx, []Waitable{a, b, c}, func(i int) (err error) {
+			switch i {
+			case 0:
+				t.First, err = a.Get(ctx)
+			case 1:
+				t.Second, err = b.Get(ctx)
+			case 2:
+				t.Third, err = c.Get(ctx)
+			}
+
+			return err
+		})
+		if err != nil {
+			return Tuple3[A, B, C]{}, err
+		}
+
+		return t, nil
+	})
+}
+
+// awaitEach waits for ws concurrently and calls read with the index of each one as soon as it's done.
+// It returns as soon as read fails or ctx is done.
+func awaitEach(ctx context.Context, ws []Waitable, read func(i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	doneCh := make(chan int, len(ws))
+	for i, w := range ws {
+		go func(i int, w Waitable) {
+			select {
+			case <-w.Done():
+				doneCh <- i
+			case <-ctx.Done():
+			}
+		}(i, w)
+	}
+
+	for range ws {
+		select {
+		case i := <-doneCh:
+			if err := read(i); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package async_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bongnv/async"
+)
+
+func TestZip(t *testing.T) {
+	t.Run("should combine both values", func(t *testing.T) {
+		a := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			return 1, nil
+		})
+		b := async.Go(context.Background(), func(ctx context.Context) (string, error) {
+			return "b", nil
+		})
+
+		resp, err := async.Zip(context.Background(), a, b).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp.First != 1 || resp.Second != "b" {
+			t.Fatalf("Expected {1 b}, but got %v", resp)
+		}
+	})
+
+	t.Run("should return the first error without waiting for the other", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		a := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+		b := async.Go(context.Background(), func(ctx context.Context) (string, error) {
+			return "", errTest
+		})
+
+		_, err := async.Zip(context.Background(), a, b).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+
+	t.Run("should return the context error when ctx is done", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		a := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+		b := async.Resolved("b")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := async.Zip(ctx, a, b).Get(context.Background())
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected %v, but got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+func TestCombine2(t *testing.T) {
+	t.Run("should combine both values", func(t *testing.T) {
+		a := async.Resolved(1)
+		b := async.Resolved("b")
+
+		resp, err := async.Combine2(context.Background(), a, b).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp.First != 1 || resp.Second != "b" {
+			t.Fatalf("Expected {1 b}, but got %v", resp)
+		}
+	})
+}
+
+func TestCombine3(t *testing.T) {
+	t.Run("should combine all values", func(t *testing.T) {
+		a := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			time.Sleep(10 * time.Millisecond)
+			return 1, nil
+		})
+		b := async.Resolved("b")
+		c := async.Resolved(true)
+
+		resp, err := async.Combine3(context.Background(), a, b, c).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if resp.First != 1 || resp.Second != "b" || !resp.Third {
+			t.Fatalf("Expected {1 b true}, but got %v", resp)
+		}
+	})
+
+	t.Run("should return an early error from the last future", func(t *testing.T) {
+		testEndCh := make(chan struct{})
+		defer close(testEndCh)
+
+		a := async.Go(context.Background(), func(ctx context.Context) (int, error) {
+			<-testEndCh
+			return 1, nil
+		})
+		b := async.Resolved("b")
+		c := async.Failed[bool](errTest)
+
+		_, err := async.Combine3(context.Background(), a, b, c).Get(context.Background())
+		if err != errTest {
+			t.Fatalf("Expected %v, but got %v", errTest, err)
+		}
+	})
+}